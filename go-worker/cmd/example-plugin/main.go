@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/plugin"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/progress"
+)
+
+type echoResult struct {
+	Echo string `json:"echo"`
+}
+
+// Пример внешнего executor'а: обслуживает тип ECHO и возвращает payload в верхнем регистре.
+// Скомпилируйте его в каталог PLUGINS_DIR, и воркер запустит его при старте.
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	info := plugin.Info{
+		Name:     "example",
+		Version:  "0.1.0",
+		JobTypes: []string{"ECHO"},
+	}
+
+	err := plugin.Serve(ctx, info, func(ctx context.Context, _, payload string) (any, error) {
+		progress.Report(ctx, progress.Update{Percent: 50, Stage: "echo"})
+		return echoResult{Echo: strings.ToUpper(payload)}, nil
+	})
+	if err != nil {
+		slog.Error("Plugin stopped with error", "error", err)
+		os.Exit(1)
+	}
+}
@@ -2,32 +2,57 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"syscall"
 	"time"
 
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/admin"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/buildinfo"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/consumer"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/dedup"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/delay"
 	_ "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/executor"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/grpc"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/middleware"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/plugin"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/ratelimit"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/reload"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/resultsink"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/spool"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/worker"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/workflow"
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
 func main() {
+	// Служебные команды: worker schemas, worker config print
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, "command failed:", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// 1. Загрузка конфигурации ДО создания контекста
-	if err := godotenv.Load(".env"); err != nil {
-		slog.Warn("No .env file found or error loading it", "error", err)
-	}
-	cfg, err := config.Load(context.Background())
+	cfg, err := loadConfig()
 	if err != nil {
-		panic("failed to load config: " + err.Error())
+		// Логирование еще не настроено: ошибки проверки выводим построчно как есть
+		fmt.Fprintln(os.Stderr, "failed to load config:", err)
+		os.Exit(1)
 	}
 
 	// 2. Настройка логирования
@@ -37,36 +62,75 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	slog.Info("Starting Job Worker Service", slog.String("env", cfg.Environment))
-
-	// 4. Валидация реестра
-	if err := jobregistry.ValidateAllTypesRegistered(); err != nil {
-		slog.Error("Registry validation failed", "error", err)
-		panic(err.Error())
-	}
+	slog.Info("Starting Job Worker Service",
+		slog.String("env", cfg.Environment),
+		slog.String("worker_id", cfg.WorkerID),
+		slog.String("version", buildinfo.GetVersion()),
+	)
 
-	// 5. Инициализация компонентов
+	// 4. Инициализация компонентов
 	components, err := initializeComponents(ctx, &cfg)
 	if err != nil {
 		slog.Error("Failed to initialize components", "error", err)
 		panic(err.Error())
 	}
 
-	// 6. Запуск компонентов
+	// 5. Запуск компонентов
 	startComponents(ctx, components)
 
-	// 7. Ожидание сигнала завершения
+	// 6. Ожидание сигнала завершения
 	<-ctx.Done()
 	slog.Info("Shutdown signal received")
 
-	// 8. Graceful shutdown с таймаутом
-	shutdownComponents(components)
+	// 7. Graceful shutdown с таймаутом
+	shutdownComponents(&cfg, components)
+}
+
+func runCommand(args []string) error {
+	switch args[0] {
+	case "schemas":
+		// JSON Schema payload'ов всех типизированных типов задач
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(jobregistry.Schemas())
+	case "config":
+		if len(args) < 2 || args[1] != "print" {
+			return errors.New("usage: worker config print")
+		}
+		// Действующая конфигурация без секретов, с учетом CONFIG_RELOAD_FILE;
+		// печатается и при ошибках проверки
+		cfg, err := loadConfig()
+		if reloadErr := cfg.ApplyReloadFile(context.Background()); reloadErr != nil {
+			err = errors.Join(err, reloadErr)
+		}
+		enc := yaml.NewEncoder(os.Stdout)
+		defer enc.Close()
+		if encErr := enc.Encode(cfg.Redacted()); encErr != nil {
+			return encErr
+		}
+		return err
+	default:
+		return fmt.Errorf("unknown command %q, available: schemas, config print", args[0])
+	}
+}
+
+// loadConfig загружает .env (если есть) и собирает конфигурацию.
+func loadConfig() (config.Config, error) {
+	if err := godotenv.Load(".env"); err != nil {
+		slog.Warn("No .env file found or error loading it", "error", err)
+	}
+	return config.Load(context.Background())
 }
 
+// logLevel — уровень логирования, меняется при hot reload.
+var logLevel = new(slog.LevelVar)
+
 func setupLogging(cfg *config.Config) {
+	logLevel.Set(cfg.ParseSlogLevel())
+
 	var handler slog.Handler
 	opts := &slog.HandlerOptions{
-		Level:     cfg.ParseSlogLevel(),
+		Level:     logLevel,
 		AddSource: cfg.DebugMode,
 	}
 
@@ -80,19 +144,44 @@ func setupLogging(cfg *config.Config) {
 
 // components содержит все компоненты системы.
 type components struct {
-	grpcClient   *grpc.GrpcClient
-	workerPool   *worker.WorkerPool
-	consumer     consumer.Consumer
-	resultSender *worker.ResultSender
-	jobsChan     chan models.Job
-	resultsChan  chan models.JobResult
+	grpcClient    *grpc.GrpcClient
+	workerPool    *worker.WorkerPool
+	consumer      consumer.Consumer
+	resultSender  *worker.ResultSender
+	registrar     *worker.Registrar
+	reloader      *reload.Reloader
+	drainer       *worker.Drainer
+	metricsServer *metrics.Server
+	adminServer   *admin.Server
+	dedupStore    dedup.Store
+	workflowStore workflow.Store
+	dispatcher    *workflow.KafkaDispatcher
+	delayStore    delay.Store
+	scheduler     *delay.Scheduler
+	destinations  []worker.ResultDestination
+	kafkaSink     *resultsink.KafkaSink // nil — результаты не публикуются в Kafka
+	pluginManager *plugin.Manager
+	shutdownTrace func(context.Context) error
+	consumerDone  chan struct{} // Закрывается, когда consumer перестал писать в jobsChan
+	jobsChan      chan models.Job
+	resultsChan   chan models.JobResult
+	progressChan  chan models.JobProgress
 }
 
 func initializeComponents(ctx context.Context, cfg *config.Config) (*components, error) {
 	c := &components{
-		jobsChan:    make(chan models.Job, cfg.JobsChannelBuffer),
-		resultsChan: make(chan models.JobResult, cfg.ResultsChannelBuffer),
+		consumerDone: make(chan struct{}),
+		jobsChan:     make(chan models.Job, cfg.JobsChannelBuffer),
+		resultsChan:  make(chan models.JobResult, cfg.ResultsChannelBuffer),
+		progressChan: make(chan models.JobProgress, cfg.ProgressChannelBuffer),
+	}
+
+	// Трассировка: до создания клиентов, которые ее используют
+	shutdownTrace, err := tracing.Setup(ctx, cfg)
+	if err != nil {
+		return nil, err
 	}
+	c.shutdownTrace = shutdownTrace
 
 	// gRPC клиент
 	grpcClient, err := grpc.NewGrpcClient(cfg)
@@ -101,23 +190,203 @@ func initializeComponents(ctx context.Context, cfg *config.Config) (*components,
 	}
 	c.grpcClient = grpcClient
 
-	// Executor'ы
-	executors := jobregistry.CreateExecutors(cfg)
+	// Внешние executor'ы: регистрируются в jobregistry до создания executor'ов
+	c.pluginManager = plugin.NewManager(cfg)
+	if err := c.pluginManager.Start(ctx); err != nil {
+		return nil, err
+	}
 
-	// Worker Pool
-	c.workerPool = worker.NewWorkerPool(cfg, c.jobsChan, c.resultsChan, executors, ctx)
+	// Rate limiter
+	limiter, err := ratelimit.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	metrics.Registry.MustRegister(limiter)
+
+	// Executor'ы: отчет о типах задач, которые обслуживает воркер.
+	// Сквозная логика подключается цепочкой middleware, первая — внешняя.
+	// Типы задач можно выключать на лету через admin API.
+	jobregistry.BuildReport().Log()
+	switches := jobregistry.NewSwitches()
+	chain := jobregistry.NewChain().Use(
+		middleware.Tracing(),
+		middleware.Recover(),
+		middleware.Logging(),
+		middleware.Metrics(),
+		middleware.Switch(switches),
+		middleware.RateLimit(limiter),
+	)
+	for name, jt := range cfg.JobTypes {
+		jobType := models.JobType(name)
+		if !slices.Contains(jobregistry.Names(), jobType) {
+			slog.Warn("Config section for unknown job type", slog.String("job_type", name))
+		}
+		if jt.Timeout > 0 {
+			chain.UseFor(jobType, middleware.Timeout(jt.Timeout))
+		}
+		if jt.Concurrency > 0 {
+			chain.UseFor(jobType, middleware.Concurrency(jt.Concurrency))
+		}
+	}
+	for topic, tc := range cfg.Topics {
+		for _, name := range tc.JobTypes {
+			if !slices.Contains(jobregistry.Names(), models.JobType(name)) {
+				slog.Warn("Topic allows unknown job type", slog.String("topic", topic), slog.String("job_type", name))
+			}
+		}
+	}
+	executors := jobregistry.CreateExecutors(cfg, chain)
+
+	// Дедупликация задач
+	dedupStore, err := dedup.NewStore(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.dedupStore = dedupStore
+	var dedupGuard *dedup.Guard
+	if dedupStore != nil {
+		dedupGuard = dedup.NewGuard(cfg, dedupStore)
+	}
+
+	// Workflow: состояние шагов и публикация следующих шагов в топик задач
+	workflowStore, err := workflow.NewStore(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.workflowStore = workflowStore
+	c.dispatcher, err = workflow.NewKafkaDispatcher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	workflows := workflow.NewCoordinator(workflowStore, c.dispatcher)
 
-	// Result Sender
+	// Пул и отправитель не зависят от сигнала остановки: взятые задачи
+	// выполняются, а результаты отправляются до дедлайнов shutdownComponents
+	workCtx := context.WithoutCancel(ctx)
+
+	// Worker Pool
+	heartbeater := grpc.NewHeartbeater(c.grpcClient, cfg.WorkerID)
+	c.workerPool = worker.NewWorkerPool(
+		cfg, c.jobsChan, c.resultsChan, c.progressChan, executors, heartbeater, dedupGuard, workflows, workCtx,
+	)
+
+	// Плагины, не запустившиеся при старте, подключаются к пулу, когда поднимутся
+	c.pluginManager.Retry(func(jobTypes []models.JobType) {
+		for _, jt := range jobTypes {
+			if exec, ok := jobregistry.CreateExecutor(cfg, chain, jt); ok {
+				c.workerPool.AddExecutor(jt, exec)
+			}
+		}
+	})
+
+	// Hot reload: размер пула, таймаут задач, уровень логов и rate limit'ы
+	c.reloader = reload.NewReloader(cfg, ctx)
+	c.reloader.OnReload(func(r config.Reloadable) error {
+		logLevel.Set(r.ParseSlogLevel())
+		return nil
+	})
+	c.reloader.OnReload(func(r config.Reloadable) error {
+		c.workerPool.Resize(r.WorkerPoolSize)
+		c.workerPool.SetJobTimeout(r.MaxJobTimeout)
+		return nil
+	})
+	c.reloader.OnReload(func(r config.Reloadable) error {
+		return limiter.Reload(r.RateLimitJobTypes, r.RateLimitHosts)
+	})
+
+	// Регистрация воркера в управляющем сервисе
+	c.registrar = worker.NewRegistrar(cfg, grpc.NewRegistrationClient(c.grpcClient), c.workerPool, ctx)
+
+	// Result Sender: получатели результатов из RESULT_SINKS, у каждого свой spool
 	resultHandler := grpc.NewResultHandler(c.grpcClient)
-	c.resultSender = worker.NewResultSender(resultHandler, c.resultsChan, ctx)
+	if err := c.initResultDestinations(cfg, resultHandler); err != nil {
+		return nil, err
+	}
+	c.resultSender = worker.NewResultSender(c.destinations, resultHandler, c.resultsChan, c.progressChan, workCtx)
+
+	// Отложенные задачи и задачи по расписанию: ждут в хранилище и передаются
+	// в пул, когда время наступило, пока чтение из Kafka не на паузе
+	c.delayStore, err = delay.NewStore(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.scheduler, err = delay.NewScheduler(cfg, c.delayStore, c.jobsChan, func() bool { return c.consumer.Paused() }, ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Kafka Consumer
-	c.consumer = consumer.NewKafkaConsumer(cfg, c.jobsChan)
+	c.consumer, err = consumer.NewKafkaConsumer(cfg, c.jobsChan, c.scheduler, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drain перед деплоем: по SIGUSR1 (SIGUSR2 — возврат в работу) или через admin API
+	c.drainer = worker.NewDrainer(c.consumer, c.workerPool, c.resultSender, ctx)
+
+	// Метрики, health-check и readiness
+	c.metricsServer = metrics.NewServer(cfg.HealthPort, c.drainer.Ready)
+
+	// Admin API
+	c.adminServer = admin.NewServer(
+		cfg, c.workerPool, c.consumer, c.drainer, switches, c.pluginManager, limiter, c.queueDepths, logLevel,
+	)
 
 	return c, nil
 }
 
+// initResultDestinations создает получателей результатов и открывает их spool'ы
+// в подкаталогах RESULTS_SPOOL_DIR.
+func (c *components) initResultDestinations(cfg *config.Config, resultHandler *grpc.ResultHandler) error {
+	for _, name := range cfg.ResultSinks {
+		var sink worker.ResultSink
+		switch name {
+		case "grpc":
+			sink = resultHandler
+		case "kafka":
+			kafkaSink, err := resultsink.NewKafkaSink(cfg)
+			if err != nil {
+				return err
+			}
+			c.kafkaSink = kafkaSink
+			sink = kafkaSink
+		}
+
+		d := worker.ResultDestination{Sink: sink}
+		if cfg.ResultsSpoolDir != "" {
+			dir := filepath.Join(cfg.ResultsSpoolDir, name)
+			if name == "grpc" {
+				// Раньше spool управляющего сервиса лежал в корне RESULTS_SPOOL_DIR
+				if err := spool.Migrate(cfg.ResultsSpoolDir, dir); err != nil {
+					return err
+				}
+			}
+			sp, err := spool.Open(name, dir,
+				cfg.ResultsSpoolMaxBytes, cfg.ResultsSpoolSegmentBytes)
+			if err != nil {
+				return err
+			}
+			d.Spool = sp
+		}
+		c.destinations = append(c.destinations, d)
+	}
+	return nil
+}
+
+// queueDepths возвращает заполненность внутренних каналов для admin API.
+func (c *components) queueDepths() []admin.QueueDepth {
+	return []admin.QueueDepth{
+		{Name: "jobs", Len: len(c.jobsChan), Cap: cap(c.jobsChan)},
+		{Name: "results", Len: len(c.resultsChan), Cap: cap(c.resultsChan)},
+		{Name: "progress", Len: len(c.progressChan), Cap: cap(c.progressChan)},
+	}
+}
+
 func startComponents(ctx context.Context, c *components) {
+	// Запуск сервера метрик и admin API
+	c.metricsServer.Start()
+	c.adminServer.Start()
+
 	// Запуск Worker Pool
 	slog.Info("Starting worker pool")
 	c.workerPool.Start()
@@ -126,9 +395,21 @@ func startComponents(ctx context.Context, c *components) {
 	slog.Info("Starting result sender")
 	c.resultSender.Start()
 
+	// Отслеживание изменений конфигурации и сигнала drain
+	c.reloader.Start()
+	c.drainer.Start()
+
+	// Регистрация воркера (и периодическое обновление нагрузки)
+	slog.Info("Starting worker registrar")
+	c.registrar.Start()
+
+	// Передача наступивших отложенных задач в пул
+	c.scheduler.Start()
+
 	// Запуск Kafka Consumer в отдельной горутине
 	slog.Info("Starting Kafka consumer")
 	go func() {
+		defer close(c.consumerDone)
 		if err := c.consumer.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
 			slog.Error("Kafka consumer error", "error", err)
 		}
@@ -137,34 +418,58 @@ func startComponents(ctx context.Context, c *components) {
 	slog.Info("All components started successfully")
 }
 
-func shutdownComponents(c *components) {
-	const shutdownTimeout = 30 * time.Second
-	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
-	defer cancel()
+// cleanupTimeout — время на закрытие соединений и серверов после отправки результатов.
+const cleanupTimeout = 2 * time.Second
 
-	slog.Info("Starting graceful shutdown", slog.Duration("timeout", shutdownTimeout))
+// shutdownComponents останавливает воркер за SHUTDOWN_TIMEOUT в три фазы:
+// остановка чтения из Kafka, выполнение взятых задач и отправка результатов.
+// Каждая фаза ограничена своим дедлайном; неотправленные результаты
+// остаются в spool до следующего запуска, брошенные задачи попадают в итоговый лог.
+func shutdownComponents(cfg *config.Config, c *components) {
+	start := time.Now()
+	executionDeadline := start.Add(cfg.ShutdownTimeout - cfg.ShutdownFlushTimeout)
+	flushDeadline := start.Add(cfg.ShutdownTimeout)
 
-	// Останавливаем Kafka consumer (перестаем принимать новые задачи)
+	slog.Info("Starting graceful shutdown", slog.Duration("timeout", cfg.ShutdownTimeout))
+
+	// Фаза 1: перестаем принимать новые задачи
 	slog.Info("Closing Kafka consumer")
-	if err := c.consumer.Close(); err != nil {
-		slog.Error("Error closing consumer", "error", err)
+	consumerStopped := stopConsumer(c, cfg.ShutdownConsumerTimeout)
+
+	// Перестаем применять новую конфигурацию и drain до остановки пула
+	c.reloader.Stop()
+	c.drainer.Stop()
+
+	// Отложенные задачи больше не передаются в пул и остаются в хранилище
+	c.scheduler.Stop()
+
+	// Канал задач закрывается, только если consumer в него больше не пишет.
+	// Иначе воркеры выбирают задачи до дедлайна выполнения, остаток бросается
+	if consumerStopped {
+		close(c.jobsChan)
 	}
 
-	// Закрываем канал задач (воркеры завершат обработку текущих)
-	slog.Info("Closing jobs channel")
-	close(c.jobsChan)
+	// Фаза 2: воркеры выполняют взятые задачи, на дедлайне задачи прерываются
+	slog.Info("Waiting for workers to finish", slog.Time("deadline", executionDeadline))
+	execCtx, cancelExec := context.WithDeadline(context.Background(), executionDeadline)
+	report := c.workerPool.Stop(execCtx)
+	cancelExec()
+	c.registrar.Stop()
 
-	// Ждем завершения всех воркеров
-	slog.Info("Waiting for workers to finish")
-	c.workerPool.Stop()
+	// Останавливаем процессы плагинов после завершения всех задач
+	slog.Info("Stopping plugins")
+	c.pluginManager.Stop()
 
-	// Закрываем канал результатов
-	slog.Info("Closing results channel")
-	close(c.resultsChan)
+	// Фаза 3: отправляем накопленные результаты, остаток сохраняется в spool
+	slog.Info("Waiting for result sender to finish", slog.Time("deadline", flushDeadline))
+	flushCtx, cancelFlush := context.WithDeadline(context.Background(), flushDeadline)
+	lost := c.resultSender.Stop(flushCtx)
+	cancelFlush()
 
-	// Ждем отправки всех результатов
-	slog.Info("Waiting for result sender to finish")
-	c.resultSender.Stop()
+	logShutdownSummary(c, report, lost, time.Since(start))
+
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
 
 	// Закрываем gRPC соединение
 	slog.Info("Closing gRPC client")
@@ -172,10 +477,115 @@ func shutdownComponents(c *components) {
 		slog.Error("Error closing gRPC client", "error", err)
 	}
 
-	select {
-	case <-ctx.Done():
-		slog.Warn("Shutdown timeout exceeded")
-	default:
-		slog.Info("Graceful shutdown completed successfully")
+	// Закрываем spool'ы результатов: недосланное останется на диске
+	for _, d := range c.destinations {
+		if d.Spool == nil {
+			continue
+		}
+		if err := d.Spool.Close(); err != nil {
+			slog.Error("Error closing results spool", slog.String("sink", d.Sink.Name()), "error", err)
+		}
+	}
+
+	// Закрываем Kafka producer результатов
+	if c.kafkaSink != nil {
+		if err := c.kafkaSink.Close(); err != nil {
+			slog.Error("Error closing Kafka result sink", "error", err)
+		}
+	}
+
+	// Закрываем producer шагов и хранилище workflow
+	if err := c.dispatcher.Close(); err != nil {
+		slog.Error("Error closing workflow dispatcher", "error", err)
+	}
+	if err := c.workflowStore.Close(); err != nil {
+		slog.Error("Error closing workflow store", "error", err)
+	}
+
+	// Закрываем хранилище отложенных задач
+	if err := c.delayStore.Close(); err != nil {
+		slog.Error("Error closing delay store", "error", err)
+	}
+
+	// Закрываем хранилище дедупликации
+	if c.dedupStore != nil {
+		if err := c.dedupStore.Close(); err != nil {
+			slog.Error("Error closing dedup store", "error", err)
+		}
+	}
+
+	// Досылаем накопленные спаны
+	if err := c.shutdownTrace(ctx); err != nil {
+		slog.Error("Error flushing traces", "error", err)
+	}
+
+	// Останавливаем admin API и сервер метрик последними
+	if err := c.adminServer.Shutdown(ctx); err != nil {
+		slog.Error("Error stopping admin API", "error", err)
+	}
+	if err := c.metricsServer.Shutdown(ctx); err != nil {
+		slog.Error("Error stopping metrics server", "error", err)
+	}
+}
+
+// stopConsumer закрывает consumer и ждет выхода из цикла чтения не дольше timeout.
+func stopConsumer(c *components, timeout time.Duration) bool {
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		if err := c.consumer.Close(); err != nil {
+			slog.Error("Error closing consumer", "error", err)
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for _, done := range []<-chan struct{}{closed, c.consumerDone} {
+		select {
+		case <-done:
+		case <-timer.C:
+			slog.Warn("Kafka consumer did not stop in time", slog.Duration("timeout", timeout))
+			return false
+		}
+	}
+	return true
+}
+
+// logShutdownSummary сообщает, что воркер не успел сделать до остановки.
+func logShutdownSummary(c *components, report worker.StopReport, lost []models.JobResult, elapsed time.Duration) {
+	jobIDs := func(jobs []worker.JobInfo) []int64 {
+		ids := make([]int64, 0, len(jobs))
+		for _, j := range jobs {
+			ids = append(ids, j.JobID)
+		}
+		return ids
+	}
+	abandonedIDs := make([]int64, 0, len(report.Abandoned))
+	for _, j := range report.Abandoned {
+		abandonedIDs = append(abandonedIDs, j.ID)
+	}
+	lostIDs := make([]int64, 0, len(lost))
+	for _, r := range lost {
+		lostIDs = append(lostIDs, r.JobID)
+	}
+
+	attrs := []any{
+		slog.Duration("elapsed", elapsed),
+		slog.Any("interrupted_jobs", jobIDs(report.Interrupted)),
+		slog.Any("stuck_jobs", jobIDs(report.Stuck)),
+		slog.Any("abandoned_jobs", abandonedIDs),
+		slog.Any("lost_results", lostIDs),
+	}
+	for _, d := range c.destinations {
+		if d.Spool != nil {
+			attrs = append(attrs, slog.Int("spooled_results_"+d.Sink.Name(), d.Spool.Len()))
+		}
+	}
+
+	if len(report.Interrupted)+len(report.Abandoned)+len(lost) > 0 {
+		slog.Warn("Graceful shutdown completed with unfinished work", attrs...)
+		return
 	}
+	slog.Info("Graceful shutdown completed successfully", attrs...)
 }
@@ -0,0 +1,333 @@
+// Package admin — HTTP API для наблюдения за работающим воркером и управления им:
+// выполняемые задачи, очереди, executor'ы, пауза чтения, drain, отмена задач
+// и уровень логирования. Все запросы требуют заголовок Authorization: Bearer ADMIN_TOKEN.
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/consumer"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/plugin"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/ratelimit"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/worker"
+)
+
+const (
+	readHeaderTimeout  = 5 * time.Second
+	maxRequestBodySize = 1 << 10
+)
+
+// Pool — воркер-пул, которым управляет admin API.
+type Pool interface {
+	InFlightJobs() []worker.JobInfo
+	Cancel(jobID int64) bool
+}
+
+// Drainer выводит воркер из работы (см. worker.Drainer).
+type Drainer interface {
+	Drain()
+	Resume()
+	State() worker.DrainState
+}
+
+// RateLimits — состояние token bucket'ов (см. ratelimit.Limiter).
+type RateLimits interface {
+	State() []ratelimit.BucketState
+}
+
+// QueueDepth — заполненность внутренней очереди (канала) воркера.
+type QueueDepth struct {
+	Name string `json:"name"`
+	Len  int    `json:"len"`
+	Cap  int    `json:"cap"`
+}
+
+type Server struct {
+	token    string
+	pool     Pool
+	consumer consumer.Consumer
+	drainer  Drainer
+	switches *jobregistry.Switches
+	plugins  *plugin.Manager // nil — плагинов нет
+	limits   RateLimits      // nil — лимитов нет
+	queues   func() []QueueDepth
+	logLevel *slog.LevelVar
+
+	httpServer *http.Server
+}
+
+func NewServer(
+	cfg *config.Config,
+	pool Pool,
+	cons consumer.Consumer,
+	drainer Drainer,
+	switches *jobregistry.Switches,
+	plugins *plugin.Manager,
+	limits RateLimits,
+	queues func() []QueueDepth,
+	logLevel *slog.LevelVar,
+) *Server {
+	s := &Server{
+		token:    cfg.AdminToken,
+		pool:     pool,
+		consumer: cons,
+		drainer:  drainer,
+		switches: switches,
+		plugins:  plugins,
+		limits:   limits,
+		queues:   queues,
+		logLevel: logLevel,
+	}
+
+	s.httpServer = &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.AdminPort),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	return s
+}
+
+// Handler возвращает обработчик всех маршрутов API с проверкой токена.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/status", s.handleStatus)
+	mux.HandleFunc("GET /admin/jobs", s.handleJobs)
+	mux.HandleFunc("POST /admin/jobs/{id}/cancel", s.handleCancelJob)
+	mux.HandleFunc("GET /admin/queues", s.handleQueues)
+	mux.HandleFunc("GET /admin/executors", s.handleExecutors)
+	mux.HandleFunc("POST /admin/executors/{type}/enable", s.handleSwitchExecutor(true))
+	mux.HandleFunc("POST /admin/executors/{type}/disable", s.handleSwitchExecutor(false))
+	mux.HandleFunc("GET /admin/ratelimits", s.handleRateLimits)
+	mux.HandleFunc("POST /admin/consumer/pause", s.handlePause)
+	mux.HandleFunc("POST /admin/consumer/resume", s.handleResume)
+	mux.HandleFunc("POST /admin/drain", s.handleDrain)
+	mux.HandleFunc("GET /admin/log-level", s.handleGetLogLevel)
+	mux.HandleFunc("PUT /admin/log-level", s.handleSetLogLevel)
+
+	return s.authenticate(mux)
+}
+
+// Start запускает HTTP сервер в отдельной горутине. Без ADMIN_TOKEN API не запускается.
+func (s *Server) Start() {
+	if s.token == "" {
+		slog.Info("Admin API disabled: ADMIN_TOKEN is not set")
+		return
+	}
+
+	go func() {
+		slog.Info("Admin API started", slog.String("addr", s.httpServer.Addr))
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Admin API error", "error", err)
+		}
+	}()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + s.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if s.token == "" || subtle.ConstantTimeCompare(got, expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type statusResponse struct {
+	Paused   bool              `json:"paused"`
+	Drain    worker.DrainState `json:"drain"` // none | draining | drained
+	InFlight int               `json:"in_flight"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+func (s *Server) status() statusResponse {
+	return statusResponse{
+		Paused:   s.consumer.Paused(),
+		Drain:    s.drainer.State(),
+		InFlight: len(s.pool.InFlightJobs()),
+	}
+}
+
+type jobResponse struct {
+	JobID     int64          `json:"job_id"`
+	Step      string         `json:"step,omitempty"`
+	Cron      string         `json:"cron,omitempty"`
+	Type      models.JobType `json:"type"`
+	Attempt   int            `json:"attempt"`
+	Slot      int            `json:"worker_slot"`
+	StartedAt time.Time      `json:"started_at"`
+	Elapsed   string         `json:"elapsed"`
+}
+
+func (s *Server) handleJobs(w http.ResponseWriter, _ *http.Request) {
+	jobs := s.pool.InFlightJobs()
+	res := make([]jobResponse, 0, len(jobs))
+	for _, j := range jobs {
+		res = append(res, jobResponse{
+			JobID:     j.JobID,
+			Step:      j.Step,
+			Cron:      j.Cron,
+			Type:      j.Type,
+			Attempt:   j.Attempt,
+			Slot:      j.Slot,
+			StartedAt: j.StartedAt,
+			Elapsed:   time.Since(j.StartedAt).Round(time.Millisecond).String(),
+		})
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleCancelJob(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid job id: %w", err))
+		return
+	}
+	if !s.pool.Cancel(jobID) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job %d is not running on this worker", jobID))
+		return
+	}
+
+	slog.Info("Admin: job cancelled", slog.Int64("job_id", jobID), slog.String("remote", r.RemoteAddr))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) handleQueues(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.queues())
+}
+
+type executorResponse struct {
+	JobType models.JobType `json:"job_type"`
+	Enabled bool           `json:"enabled"`
+	Plugin  string         `json:"plugin,omitempty"`
+	Ready   bool           `json:"ready"` // Для плагинов — проходит ли health-check
+}
+
+func (s *Server) handleExecutors(w http.ResponseWriter, _ *http.Request) {
+	plugins := make(map[models.JobType]plugin.Status)
+	if s.plugins != nil {
+		for _, st := range s.plugins.Status() {
+			for _, jt := range st.JobTypes {
+				plugins[jt] = st
+			}
+		}
+	}
+
+	names := jobregistry.Names()
+	res := make([]executorResponse, 0, len(names))
+	for _, jt := range names {
+		e := executorResponse{JobType: jt, Enabled: s.switches.Enabled(jt), Ready: true}
+		if st, ok := plugins[jt]; ok {
+			e.Plugin, e.Ready = st.Name, st.Ready
+		}
+		res = append(res, e)
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleSwitchExecutor(enabled bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobType := models.JobType(r.PathValue("type"))
+		if !slices.Contains(jobregistry.Names(), jobType) {
+			writeError(w, http.StatusNotFound, fmt.Errorf("unknown job type %q", jobType))
+			return
+		}
+
+		s.switches.Set(jobType, enabled)
+		slog.Info("Admin: executor switched",
+			slog.String("job_type", string(jobType)),
+			slog.Bool("enabled", enabled),
+			slog.String("remote", r.RemoteAddr),
+		)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) handleRateLimits(w http.ResponseWriter, _ *http.Request) {
+	res := []ratelimit.BucketState{}
+	if s.limits != nil {
+		res = s.limits.State()
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handlePause(w http.ResponseWriter, r *http.Request) {
+	s.consumer.Pause()
+	slog.Info("Admin: consumption paused", slog.String("remote", r.RemoteAddr))
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+// handleResume возобновляет чтение, в том числе после drain.
+func (s *Server) handleResume(w http.ResponseWriter, r *http.Request) {
+	s.drainer.Resume()
+	slog.Info("Admin: consumption resumed", slog.String("remote", r.RemoteAddr))
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+// handleDrain запускает drain в фоне; его ход виден в GET /admin/status.
+func (s *Server) handleDrain(w http.ResponseWriter, r *http.Request) {
+	s.drainer.Drain()
+	slog.Info("Admin: draining worker", slog.String("remote", r.RemoteAddr))
+	writeJSON(w, http.StatusAccepted, s.status())
+}
+
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+func (s *Server) handleGetLogLevel(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, logLevelRequest{Level: strings.ToLower(s.logLevel.Level().String())})
+}
+
+// handleSetLogLevel меняет уровень до следующего применения CONFIG_RELOAD_FILE.
+func (s *Server) handleSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req logLevelRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(req.Level)); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.logLevel.Set(level)
+	slog.Info("Admin: log level changed", slog.String("level", level.String()), slog.String("remote", r.RemoteAddr))
+	s.handleGetLogLevel(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Debug("Failed to write admin response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
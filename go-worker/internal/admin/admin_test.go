@@ -0,0 +1,102 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/admin"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/ratelimit"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/worker"
+)
+
+type fakePool struct{ cancelled []int64 }
+
+func (p *fakePool) InFlightJobs() []worker.JobInfo { return []worker.JobInfo{{JobID: 1}} }
+func (p *fakePool) Cancel(jobID int64) bool {
+	if jobID != 1 {
+		return false
+	}
+	p.cancelled = append(p.cancelled, jobID)
+	return true
+}
+
+type fakeConsumer struct{ paused bool }
+
+func (c *fakeConsumer) Start(context.Context) error { return nil }
+func (c *fakeConsumer) Close() error                { return nil }
+func (c *fakeConsumer) Pause()                      { c.paused = true }
+func (c *fakeConsumer) Resume()                     { c.paused = false }
+func (c *fakeConsumer) Paused() bool                { return c.paused }
+func (c *fakeConsumer) Drain(context.Context) error { c.paused = true; return nil }
+
+type fakeLimits struct{}
+
+func (fakeLimits) State() []ratelimit.BucketState {
+	return []ratelimit.BucketState{{Scope: ratelimit.ScopeHost, Key: "api.example.com", Rule: "*.example.com", Rate: 1, Burst: 1}}
+}
+
+type fakeDrainer struct{}
+
+func (fakeDrainer) Drain()                   {}
+func (fakeDrainer) Resume()                  {}
+func (fakeDrainer) State() worker.DrainState { return worker.DrainNone }
+
+func TestAdminAPI(t *testing.T) {
+	pool := &fakePool{}
+	cons := &fakeConsumer{}
+	cfg := &config.Config{AdminToken: "secret"}
+	srv := admin.NewServer(cfg, pool, cons, fakeDrainer{}, jobregistry.NewSwitches(), nil, fakeLimits{}, nil, nil)
+	handler := srv.Handler()
+
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodGet, "/admin/status", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", rec.Code)
+	}
+	if rec := do(http.MethodGet, "/admin/status", "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 with wrong token, got %d", rec.Code)
+	}
+
+	if rec := do(http.MethodPost, "/admin/jobs/1/cancel", "secret"); rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 for running job, got %d", rec.Code)
+	}
+	if rec := do(http.MethodPost, "/admin/jobs/2/cancel", "secret"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown job, got %d", rec.Code)
+	}
+	if len(pool.cancelled) != 1 {
+		t.Fatalf("expected one cancelled job, got %v", pool.cancelled)
+	}
+
+	rec := do(http.MethodGet, "/admin/ratelimits", "secret")
+	var limits []ratelimit.BucketState
+	if err := json.NewDecoder(rec.Body).Decode(&limits); err != nil {
+		t.Fatal(err)
+	}
+	if len(limits) != 1 || limits[0].Key != "api.example.com" {
+		t.Fatalf("expected host bucket in rate limits, got %+v", limits)
+	}
+
+	rec = do(http.MethodPost, "/admin/consumer/pause", "secret")
+	var status struct {
+		Paused bool `json:"paused"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if !status.Paused || !cons.paused {
+		t.Fatalf("expected consumer to be paused, got %+v", status)
+	}
+}
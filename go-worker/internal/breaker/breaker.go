@@ -0,0 +1,224 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+)
+
+// ErrOpen возвращается, когда breaker разомкнут и вызов отклонен без попытки.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State — состояние circuit breaker'а.
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// Outcome — итог вызова, разрешенного Allow.
+type Outcome int
+
+const (
+	Success Outcome = iota
+	Failure
+	// Ignored — вызов прерван самим вызывающим (например, отменен контекст задачи)
+	// и ничего не говорит о доступности зависимости.
+	Ignored
+)
+
+// OutcomeOf — Success или Failure по результату вызова.
+func OutcomeOf(success bool) Outcome {
+	if success {
+		return Success
+	}
+	return Failure
+}
+
+// Settings — параметры срабатывания breaker'а.
+type Settings struct {
+	FailureThreshold int           // Подряд идущих ошибок до размыкания
+	OpenTimeout      time.Duration // Сколько breaker остается разомкнутым до пробных вызовов
+	HalfOpenMaxCalls int           // Сколько пробных вызовов одновременно пропускается в half-open
+}
+
+func SettingsFromConfig(cfg *config.Config) Settings {
+	return Settings{
+		FailureThreshold: cfg.BreakerFailureThreshold,
+		OpenTimeout:      cfg.BreakerOpenTimeout,
+		HalfOpenMaxCalls: cfg.BreakerHalfOpenMaxCalls,
+	}
+}
+
+// Breaker — circuit breaker с состояниями closed/open/half-open.
+type Breaker struct {
+	name     string
+	group    string // Имя набора (Set), пусто — отдельный breaker со своими метриками
+	settings Settings
+
+	mu               sync.Mutex
+	state            State
+	generation       uint64 // Меняется при каждой смене состояния
+	failures         int
+	openedAt         time.Time
+	halfOpenInFlight int
+}
+
+func New(name string, settings Settings) *Breaker {
+	b := &Breaker{name: name, settings: settings}
+	stateGauge.WithLabelValues(name).Set(float64(StateClosed))
+	return b
+}
+
+// Allow проверяет, можно ли выполнить вызов. При успехе возвращает функцию,
+// которую нужно вызвать с итогом вызова. Если breaker разомкнут, возвращает ErrOpen.
+// Итог, пришедший после смены состояния (например, поздний ответ на вызов,
+// начатый до размыкания), не учитывается.
+func (b *Breaker) Allow() (func(Outcome), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.settings.OpenTimeout {
+		b.setState(StateHalfOpen)
+	}
+
+	switch b.state {
+	case StateOpen:
+		return nil, fmt.Errorf("%s: %w", b.name, ErrOpen)
+	case StateHalfOpen:
+		if b.halfOpenInFlight >= max(b.settings.HalfOpenMaxCalls, 1) {
+			return nil, fmt.Errorf("%s: %w", b.name, ErrOpen)
+		}
+		b.halfOpenInFlight++
+	case StateClosed:
+	}
+
+	generation := b.generation
+	return func(o Outcome) { b.done(generation, o) }, nil
+}
+
+// Ready сообщает, пропустит ли Allow вызов сейчас: breaker замкнут,
+// полуоткрыт или время в состоянии open истекло.
+func (b *Breaker) Ready() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state != StateOpen || time.Since(b.openedAt) >= b.settings.OpenTimeout
+}
+
+// State возвращает текущее состояние breaker'а.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *Breaker) done(generation uint64, o Outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+	if b.state == StateHalfOpen {
+		b.halfOpenInFlight--
+	}
+
+	switch o {
+	case Success:
+		b.failures = 0
+		if b.state == StateHalfOpen {
+			b.setState(StateClosed)
+		}
+	case Failure:
+		b.failures++
+		if b.state == StateHalfOpen || b.failures >= b.settings.FailureThreshold {
+			b.openedAt = time.Now()
+			b.setState(StateOpen)
+		}
+	case Ignored:
+	}
+}
+
+// setState вызывается под b.mu.
+func (b *Breaker) setState(to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	b.generation++
+	if to != StateHalfOpen {
+		b.halfOpenInFlight = 0
+	}
+
+	slog.Warn("Circuit breaker state changed",
+		slog.String("breaker", b.name),
+		slog.String("from", from.String()),
+		slog.String("to", to.String()),
+	)
+	if b.group == "" {
+		stateGauge.WithLabelValues(b.name).Set(float64(to))
+		transitionsTotal.WithLabelValues(b.name, to.String()).Inc()
+		return
+	}
+
+	// У breaker'ов набора ключей может быть сколько угодно (например, хостов),
+	// поэтому метрики ведутся по набору, а не по отдельному breaker'у
+	switch {
+	case to == StateOpen:
+		openBreakers.WithLabelValues(b.group).Inc()
+	case from == StateOpen:
+		openBreakers.WithLabelValues(b.group).Dec()
+	}
+	transitionsTotal.WithLabelValues(b.group, to.String()).Inc()
+}
+
+// Set — набор breaker'ов с общими настройками, создаваемых лениво по ключу (например, по хосту).
+type Set struct {
+	prefix   string
+	settings Settings
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+func NewSet(prefix string, settings Settings) *Set {
+	return &Set{
+		prefix:   prefix,
+		settings: settings,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Get возвращает breaker для ключа, создавая его при первом обращении.
+func (s *Set) Get(key string) *Breaker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.breakers[key]
+	if !ok {
+		b = &Breaker{name: s.prefix + ":" + key, group: s.prefix, settings: s.settings}
+		s.breakers[key] = b
+	}
+	return b
+}
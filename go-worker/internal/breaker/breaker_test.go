@@ -0,0 +1,85 @@
+package breaker_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/breaker"
+)
+
+func TestBreakerLifecycle(t *testing.T) {
+	b := breaker.New("test", breaker.Settings{
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+		HalfOpenMaxCalls: 1,
+	})
+
+	for range 2 {
+		done, err := b.Allow()
+		if err != nil {
+			t.Fatalf("closed breaker must allow calls: %v", err)
+		}
+		done(breaker.Failure)
+	}
+	if b.State() != breaker.StateOpen {
+		t.Fatalf("expected open, got %s", b.State())
+	}
+	if _, err := b.Allow(); !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("expected ErrOpen, got %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("half-open breaker must allow a probe: %v", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("second probe must be rejected, got %v", err)
+	}
+	done(breaker.Success)
+
+	if b.State() != breaker.StateClosed {
+		t.Fatalf("expected closed after successful probe, got %s", b.State())
+	}
+}
+
+func TestLateOutcomeIgnoredAfterStateChange(t *testing.T) {
+	b := breaker.New("test-late", breaker.Settings{
+		FailureThreshold: 1,
+		OpenTimeout:      20 * time.Millisecond,
+		HalfOpenMaxCalls: 1,
+	})
+
+	late, err := b.Allow()
+	if err != nil {
+		t.Fatalf("closed breaker must allow calls: %v", err)
+	}
+	done, _ := b.Allow()
+	done(breaker.Failure)
+
+	time.Sleep(30 * time.Millisecond)
+	probe, err := b.Allow()
+	if err != nil {
+		t.Fatalf("half-open breaker must allow a probe: %v", err)
+	}
+
+	// Ответ на вызов, начатый до размыкания, не закрывает breaker и не освобождает слот пробы
+	late(breaker.Success)
+	if b.State() != breaker.StateHalfOpen {
+		t.Fatalf("late outcome must not change state, got %s", b.State())
+	}
+	if _, err := b.Allow(); !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("probe slot must still be taken, got %v", err)
+	}
+
+	// Прерванная проба освобождает слот, но не влияет на состояние
+	probe(breaker.Ignored)
+	if b.State() != breaker.StateHalfOpen {
+		t.Fatalf("ignored outcome must not change state, got %s", b.State())
+	}
+	if _, err := b.Allow(); err != nil {
+		t.Fatalf("expected a new probe after ignored one: %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package breaker
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	stateGauge = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "circuit_breaker",
+		Name:      "state",
+		Help:      "Circuit breaker state: 0 - closed, 1 - half-open, 2 - open.",
+	}, []string{"breaker"})
+
+	openBreakers = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "circuit_breaker",
+		Name:      "open",
+		Help:      "Open circuit breakers in a keyed set (e.g. per HTTP host).",
+	}, []string{"set"})
+
+	transitionsTotal = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "circuit_breaker",
+		Name:      "transitions_total",
+		Help:      "Circuit breaker state transitions by target state; keyed sets are reported by set name.",
+	}, []string{"breaker", "to"})
+)
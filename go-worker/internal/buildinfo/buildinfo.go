@@ -0,0 +1,29 @@
+package buildinfo
+
+import "runtime/debug"
+
+// Version задается при сборке:
+//
+//	go build -ldflags="-X github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/buildinfo.Version=1.2.3"
+var Version = ""
+
+// GetVersion возвращает версию сборки. Без ldflags — ревизию VCS из debug.BuildInfo.
+func GetVersion() string {
+	if Version != "" {
+		return Version
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" {
+			return s.Value
+		}
+	}
+	if info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "unknown"
+}
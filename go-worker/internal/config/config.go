@@ -3,7 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
-	"log/slog"
+	"os"
 	"strings"
 	"time"
 
@@ -11,64 +11,267 @@ import (
 )
 
 type Config struct {
+	// YAML файл с базовой конфигурацией, переменные окружения имеют приоритет
+	ConfigFile string `env:"CONFIG_FILE"`
+
 	// Kafka
-	KafkaBrokers     string   `env:"KAFKA_BROKERS,required"`
-	KafkaBrokersList []string // Заполняется в main после парсинга
+	KafkaBrokers     string   `env:"KAFKA_BROKERS"`
+	KafkaBrokersList []string // Заполняется в Load из KafkaBrokers
 	KafkaTopic       string   `env:"KAFKA_TOPIC,default=job_requests"`
-	KafkaGroupID     string   `env:"KAFKA_GROUP_ID,required"`
+	KafkaGroupID     string   `env:"KAFKA_GROUP_ID"`
 	KafkaClientID    string   `env:"KAFKA_CLIENT_ID,default=go-worker"`
 
+	// Чтение нескольких топиков одной consumer group: список или регулярное
+	// выражение имен (вместо KAFKA_TOPIC). Новые топики, подходящие под
+	// выражение, подхватываются раз в KAFKA_TOPIC_REFRESH_INTERVAL
+	KafkaTopics               []string      `env:"KAFKA_TOPICS"`
+	KafkaTopicPattern         string        `env:"KAFKA_TOPIC_PATTERN"`
+	KafkaTopicRefreshInterval time.Duration `env:"KAFKA_TOPIC_REFRESH_INTERVAL,default=1m"`
+
+	// Kafka TLS: CA и клиентский сертификат (mTLS) — необязательны
+	KafkaTLSEnabled            bool   `env:"KAFKA_TLS_ENABLED,default=false"`
+	KafkaTLSCAFile             string `env:"KAFKA_TLS_CA_FILE"`
+	KafkaTLSCertFile           string `env:"KAFKA_TLS_CERT_FILE"`
+	KafkaTLSKeyFile            string `env:"KAFKA_TLS_KEY_FILE"`
+	KafkaTLSInsecureSkipVerify bool   `env:"KAFKA_TLS_INSECURE_SKIP_VERIFY,default=false"`
+
+	// Kafka SASL
+	KafkaSASLMechanism string `env:"KAFKA_SASL_MECHANISM,default=none"` // none | plain | scram-sha-256 | scram-sha-512
+	KafkaSASLUsername  string `env:"KAFKA_SASL_USERNAME"`
+	KafkaSASLPassword  string `env:"KAFKA_SASL_PASSWORD" secret:"true"`
+
+	// Настройки чтения и consumer group
+	KafkaDialTimeout       time.Duration `env:"KAFKA_DIAL_TIMEOUT,default=10s"`
+	KafkaMinBytes          int           `env:"KAFKA_MIN_BYTES,default=1"`
+	KafkaMaxBytes          int           `env:"KAFKA_MAX_BYTES,default=10000000"` // Максимальный размер fetch-ответа, 10MB
+	KafkaMaxWait           time.Duration `env:"KAFKA_MAX_WAIT,default=10s"`
+	KafkaSessionTimeout    time.Duration `env:"KAFKA_SESSION_TIMEOUT,default=30s"`
+	KafkaHeartbeatInterval time.Duration `env:"KAFKA_HEARTBEAT_INTERVAL,default=3s"`
+	KafkaRebalanceTimeout  time.Duration `env:"KAFKA_REBALANCE_TIMEOUT,default=30s"`
+	KafkaRebalanceStrategy string        `env:"KAFKA_REBALANCE_STRATEGY,default=range"` // range | roundrobin
+	KafkaStartOffset       string        `env:"KAFKA_START_OFFSET,default=latest"`      // latest | earliest, для новой группы
+
 	// gRPC
-	GrpcServerAddress string        `env:"GRPC_SERVER_ADDRESS,required"`
+	GrpcServerAddress string        `env:"GRPC_SERVER_ADDRESS"`
 	GrpcTimeout       time.Duration `env:"GRPC_TIMEOUT,default=5s"`
 
+	// gRPC TLS: файлы перечитываются при ротации, без перезапуска
+	GrpcTLSEnabled    bool   `env:"GRPC_TLS_ENABLED,default=false"`
+	GrpcTLSCAFile     string `env:"GRPC_TLS_CA_FILE"`   // По умолчанию — системные CA
+	GrpcTLSCertFile   string `env:"GRPC_TLS_CERT_FILE"` // Клиентский сертификат для mTLS
+	GrpcTLSKeyFile    string `env:"GRPC_TLS_KEY_FILE"`
+	GrpcTLSServerName string `env:"GRPC_TLS_SERVER_NAME"` // Переопределяет имя сервера для проверки сертификата
+
+	// Bearer-токен воркера для управляющего сервиса: значение или файл (перечитывается при изменении)
+	GrpcAuthToken     string `env:"GRPC_AUTH_TOKEN" secret:"true"`
+	GrpcAuthTokenFile string `env:"GRPC_AUTH_TOKEN_FILE"`
+
+	// Настройки, которые применяются без перезапуска: размер пула,
+	// таймаут задачи, уровень логирования и rate limit'ы (см. reload.go)
+	Reloadable
+
 	// Worker Pool
-	WorkerPoolSize       int           `env:"WORKER_POOL_SIZE,default=10"`
-	MaxJobTimeout        time.Duration `env:"MAX_JOB_TIMEOUT,default=30s"`
-	JobsChannelBuffer    int           `env:"JOBS_CHANNEL_BUFFER,default=100"`
-	ResultsChannelBuffer int           `env:"RESULTS_CHANNEL_BUFFER,default=100"`
+	JobsChannelBuffer    int `env:"JOBS_CHANNEL_BUFFER,default=100"`
+	ResultsChannelBuffer int `env:"RESULTS_CHANNEL_BUFFER,default=100"`
+
+	// Heartbeat
+	WorkerID          string        `env:"WORKER_ID"` // По умолчанию — hostname
+	HeartbeatInterval time.Duration `env:"HEARTBEAT_INTERVAL,default=10s"`
+
+	// Регистрация воркера: как часто сообщать возможности и нагрузку
+	RegistrationInterval time.Duration `env:"REGISTRATION_INTERVAL,default=30s"`
+
+	// Progress
+	ProgressInterval      time.Duration `env:"PROGRESS_INTERVAL,default=1s"`
+	ProgressChannelBuffer int           `env:"PROGRESS_CHANNEL_BUFFER,default=100"`
+
+	// Circuit breakers (HTTP хосты и gRPC сервер)
+	BreakerFailureThreshold int           `env:"BREAKER_FAILURE_THRESHOLD,default=5"`
+	BreakerOpenTimeout      time.Duration `env:"BREAKER_OPEN_TIMEOUT,default=30s"`
+	BreakerHalfOpenMaxCalls int           `env:"BREAKER_HALF_OPEN_MAX_CALLS,default=1"`
+
+	// Dedup: подавление повторного выполнения одной задачи
+	DedupStore       string        `env:"DEDUP_STORE,default=none"` // none | memory | postgres
+	DedupTTL         time.Duration `env:"DEDUP_TTL,default=24h"`
+	DedupCacheSize   int           `env:"DEDUP_CACHE_SIZE,default=10000"`
+	DedupPostgresDSN string        `env:"DEDUP_POSTGRES_DSN" secret:"true"`
+
+	// Workflow: состояние выполняемых workflow. memory подходит только для одного
+	// воркера: шаги workflow выполняют разные воркеры
+	WorkflowStore       string        `env:"WORKFLOW_STORE,default=memory"` // memory | postgres
+	WorkflowPostgresDSN string        `env:"WORKFLOW_POSTGRES_DSN" secret:"true"`
+	WorkflowTTL         time.Duration `env:"WORKFLOW_TTL,default=24h"` // Состояние зависшего workflow удаляется после TTL без изменений
+
+	// Отложенные задачи (JobTask.not_before) и задачи по расписанию (секция cron).
+	// file — каталог на диске одного воркера, postgres — общее хранилище всех воркеров
+	DelayStore        string        `env:"DELAY_STORE,default=file"` // file | postgres
+	DelayDir          string        `env:"DELAY_DIR,default=delayed"`
+	DelayPostgresDSN  string        `env:"DELAY_POSTGRES_DSN" secret:"true"`
+	DelayPollInterval time.Duration `env:"DELAY_POLL_INTERVAL,default=1s"` // Период проверки наступивших задач
+
+	// Внешние executor'ы (плагины)
+	PluginsDir              string        `env:"PLUGINS_DIR"` // Каталог исполняемых файлов плагинов
+	Plugins                 []string      `env:"PLUGINS"`     // Уже запущенные плагины: name=address
+	PluginStartTimeout      time.Duration `env:"PLUGIN_START_TIMEOUT,default=10s"`
+	PluginHealthInterval    time.Duration `env:"PLUGIN_HEALTH_INTERVAL,default=10s"`
+	PluginHealthTimeout     time.Duration `env:"PLUGIN_HEALTH_TIMEOUT,default=2s"`
+	PluginMaxHealthFailures int           `env:"PLUGIN_MAX_HEALTH_FAILURES,default=3"`
+	PluginRestartBackoff    time.Duration `env:"PLUGIN_RESTART_BACKOFF,default=1s"`
+
+	// Hot reload: файл в формате .env с переопределениями Reloadable.
+	// Перечитывается при изменении и по SIGHUP
+	ReloadFile     string        `env:"CONFIG_RELOAD_FILE"`
+	ReloadInterval time.Duration `env:"CONFIG_RELOAD_INTERVAL,default=5s"` // Период проверки файла
+
+	// Остановка: общий лимит и доли фаз. Остаток после остановки чтения
+	// и до отправки результатов отдается выполнению взятых задач
+	ShutdownTimeout         time.Duration `env:"SHUTDOWN_TIMEOUT,default=25s"` // Меньше terminationGracePeriodSeconds (30s)
+	ShutdownConsumerTimeout time.Duration `env:"SHUTDOWN_CONSUMER_TIMEOUT,default=5s"`
+	ShutdownFlushTimeout    time.Duration `env:"SHUTDOWN_FLUSH_TIMEOUT,default=5s"`
+
+	// Получатели результатов: grpc (управляющий сервис), kafka (топик для
+	// аналитики и биллинга) или оба. Каждый получатель доставляет результат независимо
+	ResultSinks       []string      `env:"RESULT_SINKS,default=grpc"`
+	KafkaResultsTopic string        `env:"KAFKA_RESULTS_TOPIC,default=job_results"`
+	KafkaWriteTimeout time.Duration `env:"KAFKA_WRITE_TIMEOUT,default=10s"`
+
+	// Spool результатов на диске: результаты, которые не удалось отправить,
+	// сохраняются и досылаются по порядку. Пустой каталог — spool отключен
+	ResultsSpoolDir          string `env:"RESULTS_SPOOL_DIR,default=spool"`
+	ResultsSpoolMaxBytes     int64  `env:"RESULTS_SPOOL_MAX_BYTES,default=268435456"`   // 256MB; при заполнении отправка блокирует прием задач
+	ResultsSpoolSegmentBytes int64  `env:"RESULTS_SPOOL_SEGMENT_BYTES,default=4194304"` // 4MB
 
 	// Logging
-	LogLevel  string `env:"LOG_LEVEL,default=info"`
 	LogFormat string `env:"LOG_FORMAT,default=json"`
 
+	// Сбор лога выполнения задачи в результат: none | failed | all
+	JobLogCapture         string `env:"JOB_LOG_CAPTURE,default=none"`
+	JobLogCaptureMaxBytes int    `env:"JOB_LOG_CAPTURE_MAX_BYTES,default=16384"`
+
 	// Health
 	HealthPort int `env:"HEALTH_PORT,default=8765"`
 
+	// Admin API: включается, только если задан токен
+	AdminPort  int    `env:"ADMIN_PORT,default=8766"`
+	AdminToken string `env:"ADMIN_TOKEN" secret:"true"`
+
+	// Tracing (OpenTelemetry)
+	TracingExporter     string  `env:"TRACING_EXPORTER,default=none"` // none | otlp | stdout
+	TracingOTLPEndpoint string  `env:"TRACING_OTLP_ENDPOINT,default=localhost:4317"`
+	TracingOTLPInsecure bool    `env:"TRACING_OTLP_INSECURE,default=false"`
+	TracingSampleRatio  float64 `env:"TRACING_SAMPLE_RATIO,default=1"` // Доля трасс, начатых воркером
+	TracingServiceName  string  `env:"TRACING_SERVICE_NAME,default=job-worker"`
+
 	// Other
 	Environment string `env:"ENVIRONMENT,default=production"`
 	DebugMode   bool   `env:"DEBUG_MODE,default=false"`
+
+	// Настройки отдельных типов задач из секции job_types файла CONFIG_FILE
+	JobTypes map[string]JobTypeConfig
+
+	// Задачи по расписанию из секции cron файла CONFIG_FILE
+	Cron []CronJobConfig
+
+	// Настройки отдельных топиков задач из секции topics файла CONFIG_FILE
+	Topics map[string]TopicConfig
+}
+
+// JobTypeConfig — настройки одного типа задач.
+type JobTypeConfig struct {
+	Timeout     time.Duration     `yaml:"timeout"`     // Не больше MAX_JOB_TIMEOUT, 0 — без отдельного лимита
+	Concurrency int               `yaml:"concurrency"` // Максимум одновременных задач типа, 0 — без ограничения
+	Options     map[string]string `yaml:"options"`     // Параметры executor'а
+}
+
+// TopicConfig — настройки задач из одного топика Kafka.
+type TopicConfig struct {
+	Concurrency int      `yaml:"concurrency"` // Максимум одновременных задач из топика, 0 — без ограничения
+	JobTypes    []string `yaml:"job_types"`   // Разрешенные типы задач, пустой список — все
+}
+
+// CronJobConfig — задача, которая запускается по расписанию.
+type CronJobConfig struct {
+	Name     string         `yaml:"name"`
+	Schedule string         `yaml:"schedule"` // Cron-выражение из 5 полей или @daily, @hourly, ...
+	Timezone string         `yaml:"timezone"` // Часовой пояс расписания, по умолчанию UTC
+	Type     string         `yaml:"type"`
+	Payload  map[string]any `yaml:"payload"`
 }
 
+// Load собирает конфигурацию из YAML файла CONFIG_FILE (если задан)
+// и переменных окружения поверх него, затем проверяет ее.
 func Load(ctx context.Context) (Config, error) {
 	var cfg Config
 
-	if err := envconfig.Process(ctx, &cfg); err != nil {
+	file, err := readFile(os.Getenv(FileEnv))
+	if err != nil {
+		return cfg, err
+	}
+
+	lookuper := envconfig.MultiLookuper(envconfig.OsLookuper(), envconfig.MapLookuper(file.values))
+	if err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &cfg, Lookuper: lookuper}); err != nil {
 		return cfg, fmt.Errorf("failed to process environment: %w", err)
 	}
+	cfg.JobTypes = file.jobTypes
+	cfg.Cron = file.cron
+	cfg.Topics = file.topics
+	cfg.KafkaBrokersList = splitList(cfg.KafkaBrokers)
+
+	if cfg.WorkerID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return cfg, fmt.Errorf("failed to resolve worker id: %w", err)
+		}
+		cfg.WorkerID = hostname
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
 
 	return cfg, nil
 }
 
-func (c Config) ParseSlogLevel() slog.Level {
-	switch strings.ToLower(c.LogLevel) {
-	case "debug":
-		return slog.LevelDebug
-	case "info":
-		return slog.LevelInfo
-	case "warn":
-		return slog.LevelWarn
-	case "error":
-		return slog.LevelError
+// JobType возвращает настройки типа задач; для типа без секции — нулевые.
+func (c *Config) JobType(name string) JobTypeConfig {
+	return c.JobTypes[name]
+}
+
+// ConsumerTopics возвращает топики, которые читает воркер, если они заданы
+// списком (KAFKA_TOPICS или KAFKA_TOPIC). При KAFKA_TOPIC_PATTERN — nil:
+// топики определяются по метаданным кластера.
+func (c *Config) ConsumerTopics() []string {
+	switch {
+	case c.KafkaTopicPattern != "":
+		return nil
+	case len(c.KafkaTopics) > 0:
+		return c.KafkaTopics
 	default:
-		return slog.LevelInfo
+		return []string{c.KafkaTopic}
 	}
 }
 
 func (c Config) String() string {
 	return fmt.Sprintf(
-		"kafka=%s, topic=%s, group=%s, grpc=%s, workers=%d",
-		c.KafkaBrokers, c.KafkaTopic, c.KafkaGroupID,
+		"kafka=%s, topics=%s, group=%s, grpc=%s, workers=%d",
+		c.KafkaBrokers, c.topicsString(), c.KafkaGroupID,
 		c.GrpcServerAddress, c.WorkerPoolSize,
 	)
 }
+
+func (c Config) topicsString() string {
+	if c.KafkaTopicPattern != "" {
+		return "/" + c.KafkaTopicPattern + "/"
+	}
+	return strings.Join(c.ConsumerTopics(), ",")
+}
+
+func splitList(s string) []string {
+	var res []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
@@ -0,0 +1,162 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+)
+
+const testFile = `
+kafka_brokers: "kafka-1:9092, kafka-2:9092"
+kafka_group_id: workers
+grpc_server_address: core:9090
+worker_pool_size: 4
+rate_limit_hosts: ["*.example.com=5:10", "*=50"]
+dedup_store: postgres
+dedup_postgres_dsn: postgres://user:secret@db/jobs
+job_types:
+  HTTP_GET:
+    timeout: 10s
+    concurrency: 2
+    options:
+      user_agent: job-worker
+`
+
+func TestLoadLayersEnvOverFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worker.yaml")
+	if err := os.WriteFile(path, []byte(testFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.FileEnv, path)
+	t.Setenv("WORKER_POOL_SIZE", "8")
+
+	cfg, err := config.Load(context.Background())
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if cfg.WorkerPoolSize != 8 {
+		t.Errorf("env must override file, got pool size %d", cfg.WorkerPoolSize)
+	}
+	if len(cfg.KafkaBrokersList) != 2 || cfg.KafkaBrokersList[1] != "kafka-2:9092" {
+		t.Errorf("unexpected brokers list %v", cfg.KafkaBrokersList)
+	}
+	if len(cfg.RateLimitHosts) != 2 {
+		t.Errorf("expected list from YAML sequence, got %v", cfg.RateLimitHosts)
+	}
+	httpGet := cfg.JobType("HTTP_GET")
+	if httpGet.Timeout != 10*time.Second || httpGet.Concurrency != 2 || httpGet.Options["user_agent"] != "job-worker" {
+		t.Errorf("unexpected job type section %+v", httpGet)
+	}
+
+	printed := cfg.Redacted()
+	if printed["dedup_postgres_dsn"] == "postgres://user:secret@db/jobs" {
+		t.Error("secret must be redacted")
+	}
+	if printed["worker_pool_size"] != 8 {
+		t.Errorf("expected effective value in print, got %v", printed["worker_pool_size"])
+	}
+}
+
+func TestApplyReloadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reload.env")
+	cfg := config.Config{
+		Reloadable: config.Reloadable{WorkerPoolSize: 10, MaxJobTimeout: 30 * time.Second, LogLevel: "info"},
+		ReloadFile: path,
+		JobTypes:   map[string]config.JobTypeConfig{"HTTP_GET": {Timeout: 10 * time.Second}},
+	}
+
+	// MAX_JOB_TIMEOUT из файла меньше таймаута секции job_types
+	if err := os.WriteFile(path, []byte("WORKER_POOL_SIZE=3\nMAX_JOB_TIMEOUT=5s\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err := cfg.ApplyReloadFile(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "job_types.HTTP_GET.timeout") {
+		t.Fatalf("expected job_types timeout error, got %v", err)
+	}
+	if cfg.WorkerPoolSize != 10 {
+		t.Errorf("invalid reload file must not be applied, got pool size %d", cfg.WorkerPoolSize)
+	}
+
+	if err := os.WriteFile(path, []byte("WORKER_POOL_SIZE=3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.ApplyReloadFile(context.Background()); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if printed := cfg.Redacted(); printed["worker_pool_size"] != 3 {
+		t.Errorf("expected value from reload file in print, got %v", printed["worker_pool_size"])
+	}
+}
+
+func TestValidateAggregatesErrors(t *testing.T) {
+	cfg := config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 0,
+			MaxJobTimeout:  -time.Second,
+			LogLevel:       "info",
+		},
+		LogFormat: "json",
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"KAFKA_BROKERS", "KAFKA_TOPIC", "WORKER_POOL_SIZE", "MAX_JOB_TIMEOUT", "DEDUP_STORE"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %s in error:\n%v", want, err)
+		}
+	}
+}
+
+func TestValidateTopicSections(t *testing.T) {
+	cfg := config.Config{
+		KafkaTopic:                "job_requests",
+		KafkaTopicPattern:         `jobs\.tenant-.*`,
+		KafkaTopicRefreshInterval: time.Minute,
+		Topics: map[string]config.TopicConfig{
+			"jobs.tenant-a":        {Concurrency: 2},
+			"jobs.tenant-b.extra1": {JobTypes: []string{"SLEEP"}},
+			"other.tenant-c":       {Concurrency: -1},
+		},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{
+		`topics.other.tenant-c does not match KAFKA_TOPIC_PATTERN`,
+		"topics.other.tenant-c.concurrency must not be negative",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "topics.jobs.tenant-") {
+		t.Errorf("unexpected error for matching topics:\n%v", err)
+	}
+}
+
+func TestTopicPatternMatchesWholeName(t *testing.T) {
+	pattern, err := config.TopicPattern(`jobs\.[a-z]|jobs\.a\.dlq`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for topic, want := range map[string]bool{
+		"jobs.a":     true,
+		"jobs.a.dlq": true,
+		"jobs.ab":    false,
+		"xjobs.a":    false,
+	} {
+		if got := pattern.MatchString(topic); got != want {
+			t.Errorf("match %q = %v, want %v", topic, got, want)
+		}
+	}
+}
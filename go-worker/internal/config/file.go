@@ -0,0 +1,166 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv — переменная окружения с путем к YAML файлу конфигурации.
+const FileEnv = "CONFIG_FILE"
+
+const (
+	jobTypesSection = "job_types"
+	cronSection     = "cron"
+	topicsSection   = "topics"
+)
+
+// fileConfig — содержимое YAML файла: значения переменных (ключи — имена
+// переменных окружения в нижнем регистре) и секции job_types, cron и topics.
+type fileConfig struct {
+	values   map[string]string
+	jobTypes map[string]JobTypeConfig
+	cron     []CronJobConfig
+	topics   map[string]TopicConfig
+}
+
+// readFile читает YAML файл конфигурации. Пустой путь — пустая конфигурация.
+//
+//	worker_pool_size: 20
+//	rate_limit_hosts: ["*.example.com=5:10", "*=50"]
+//	job_types:
+//	  HTTP_GET:
+//	    timeout: 10s
+//	    concurrency: 5
+//	    options:
+//	      user_agent: job-worker
+//	cron:
+//	  - name: nightly-report
+//	    schedule: "0 3 * * *"
+//	    type: HTTP_GET
+//	    payload:
+//	      url: https://example.com/report
+//	topics:
+//	  jobs.tenant-a:
+//	    concurrency: 4
+//	    job_types: [HTTP_GET, SLEEP]
+func readFile(path string) (fileConfig, error) {
+	file := fileConfig{values: make(map[string]string)}
+	if path == "" {
+		return file, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return file, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	known := envNames()
+	var errs []error
+	for key, node := range raw {
+		if key == jobTypesSection {
+			if err := decodeStrict(&node, &file.jobTypes); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", jobTypesSection, err))
+			}
+			continue
+		}
+		if key == cronSection {
+			if err := decodeStrict(&node, &file.cron); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", cronSection, err))
+			}
+			continue
+		}
+		if key == topicsSection {
+			if err := decodeStrict(&node, &file.topics); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", topicsSection, err))
+			}
+			continue
+		}
+
+		name := strings.ToUpper(key)
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("%s: unknown setting", key))
+			continue
+		}
+
+		value, err := scalarValue(&node)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			continue
+		}
+		file.values[name] = value
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return file, fmt.Errorf("invalid config file %s:\n%w", path, err)
+	}
+	return file, nil
+}
+
+// scalarValue приводит значение YAML к строке в формате переменной
+// окружения: списки склеиваются через запятую.
+func scalarValue(node *yaml.Node) (string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value, nil
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return "", errors.New("list items must be scalars")
+			}
+			items = append(items, item.Value)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", errors.New("expected a scalar or a list")
+	}
+}
+
+// decodeStrict декодирует узел YAML, не допуская неизвестных полей.
+func decodeStrict(node *yaml.Node, target any) error {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	return dec.Decode(target)
+}
+
+// envNames возвращает имена всех переменных окружения конфигурации.
+func envNames() map[string]bool {
+	names := make(map[string]bool)
+	walkEnv(reflect.ValueOf(Config{}), func(name string, _ reflect.StructField, _ reflect.Value) {
+		names[name] = true
+	})
+	return names
+}
+
+// walkEnv обходит поля с тегом env, включая встроенные структуры.
+func walkEnv(v reflect.Value, fn func(name string, field reflect.StructField, value reflect.Value)) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			walkEnv(v.Field(i), fn)
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		if name == "" {
+			continue
+		}
+		fn(name, field, v.Field(i))
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+const redacted = "<redacted>"
+
+// Redacted возвращает действующую конфигурацию в формате файла CONFIG_FILE
+// (ключи — имена переменных в нижнем регистре). Значения полей с тегом
+// secret:"true" скрываются.
+func (c Config) Redacted() map[string]any {
+	res := make(map[string]any)
+	walkEnv(reflect.ValueOf(c), func(name string, field reflect.StructField, value reflect.Value) {
+		key := strings.ToLower(name)
+		switch {
+		case field.Tag.Get("secret") == "true":
+			if !value.IsZero() {
+				res[key] = redacted
+			} else {
+				res[key] = ""
+			}
+		case value.Type() == reflect.TypeFor[time.Duration]():
+			res[key] = time.Duration(value.Int()).String()
+		default:
+			res[key] = value.Interface()
+		}
+	})
+
+	if len(c.JobTypes) > 0 {
+		jobTypes := make(map[string]any, len(c.JobTypes))
+		for name, jt := range c.JobTypes {
+			section := map[string]any{
+				"timeout":     jt.Timeout.String(),
+				"concurrency": jt.Concurrency,
+			}
+			if len(jt.Options) > 0 {
+				section["options"] = jt.Options
+			}
+			jobTypes[name] = section
+		}
+		res[jobTypesSection] = jobTypes
+	}
+
+	if len(c.Cron) > 0 {
+		cron := make([]map[string]any, 0, len(c.Cron))
+		for _, job := range c.Cron {
+			entry := map[string]any{
+				"name":     job.Name,
+				"schedule": job.Schedule,
+				"type":     job.Type,
+			}
+			if job.Timezone != "" {
+				entry["timezone"] = job.Timezone
+			}
+			if len(job.Payload) > 0 {
+				entry["payload"] = job.Payload
+			}
+			cron = append(cron, entry)
+		}
+		res[cronSection] = cron
+	}
+
+	if len(c.Topics) > 0 {
+		topics := make(map[string]any, len(c.Topics))
+		for name, tc := range c.Topics {
+			section := map[string]any{"concurrency": tc.Concurrency}
+			if len(tc.JobTypes) > 0 {
+				section["job_types"] = tc.JobTypes
+			}
+			topics[name] = section
+		}
+		res[topicsSection] = topics
+	}
+
+	return res
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// Reloadable — подмножество конфигурации, которое перечитывается
+// и применяется на лету, без перезапуска воркера и ребалансировки Kafka.
+type Reloadable struct {
+	WorkerPoolSize int           `env:"WORKER_POOL_SIZE,default=10"`
+	MaxJobTimeout  time.Duration `env:"MAX_JOB_TIMEOUT,default=30s"`
+	LogLevel       string        `env:"LOG_LEVEL,default=info"`
+
+	// Rate limiting: правила вида "pattern=rate[:burst]" через запятую
+	RateLimitJobTypes []string `env:"RATE_LIMIT_JOB_TYPES"` // Например: HTTP_GET=10:20
+	RateLimitHosts    []string `env:"RATE_LIMIT_HOSTS"`     // Например: *.example.com=5:10,*=50
+}
+
+const maxWorkerPoolSize = 1000
+
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// LoadReloadable читает Reloadable так же, как Load: окружение поверх
+// YAML файла CONFIG_FILE. Если path задан, значения из него (формат .env)
+// имеют приоритет над остальными источниками.
+func LoadReloadable(ctx context.Context, path string) (Reloadable, error) {
+	var r Reloadable
+
+	file, err := readFile(os.Getenv(FileEnv))
+	if err != nil {
+		return r, err
+	}
+	lookupers := []envconfig.Lookuper{envconfig.OsLookuper(), envconfig.MapLookuper(file.values)}
+
+	if path != "" {
+		values, err := godotenv.Read(path)
+		if err != nil {
+			return r, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		lookupers = slices.Insert(lookupers, 0, envconfig.MapLookuper(values))
+	}
+
+	lookuper := envconfig.MultiLookuper(lookupers...)
+	if err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &r, Lookuper: lookuper}); err != nil {
+		return r, fmt.Errorf("failed to process reloadable config: %w", err)
+	}
+	return r, nil
+}
+
+// Validate проверяет значения до их применения.
+func (r Reloadable) Validate() error {
+	var errs []error
+	if r.WorkerPoolSize < 1 || r.WorkerPoolSize > maxWorkerPoolSize {
+		errs = append(errs, fmt.Errorf("WORKER_POOL_SIZE must be in [1, %d], got %d", maxWorkerPoolSize, r.WorkerPoolSize))
+	}
+	if r.MaxJobTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("MAX_JOB_TIMEOUT must be positive, got %s", r.MaxJobTimeout))
+	}
+	if !slices.Contains(logLevels, strings.ToLower(r.LogLevel)) {
+		errs = append(errs, fmt.Errorf("LOG_LEVEL must be one of %v, got %q", logLevels, r.LogLevel))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateJobTypes проверяет, что таймауты секций job_types не превышают
+// MAX_JOB_TIMEOUT. Нужна и при перезагрузке: MAX_JOB_TIMEOUT может уменьшиться.
+func (r Reloadable) ValidateJobTypes(jobTypes map[string]JobTypeConfig) error {
+	names := make([]string, 0, len(jobTypes))
+	for name := range jobTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if timeout := jobTypes[name].Timeout; timeout < 0 || timeout > r.MaxJobTimeout {
+			errs = append(errs, fmt.Errorf("job_types.%s.timeout must be in [0, MAX_JOB_TIMEOUT=%s], got %s",
+				name, r.MaxJobTimeout, timeout))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ApplyReloadFile накладывает на конфигурацию значения из CONFIG_RELOAD_FILE
+// так же, как их применяет воркер при старте.
+func (c *Config) ApplyReloadFile(ctx context.Context) error {
+	if c.ReloadFile == "" {
+		return nil
+	}
+
+	r, err := LoadReloadable(ctx, c.ReloadFile)
+	if err != nil {
+		return err
+	}
+	if err := errors.Join(r.Validate(), r.ValidateJobTypes(c.JobTypes)); err != nil {
+		return fmt.Errorf("CONFIG_RELOAD_FILE %s: %w", c.ReloadFile, err)
+	}
+	c.Reloadable = r
+	return nil
+}
+
+func (r Reloadable) ParseSlogLevel() slog.Level {
+	switch strings.ToLower(r.LogLevel) {
+	case "debug":
+		return slog.LevelDebug
+	case "info":
+		return slog.LevelInfo
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Diff возвращает изменения относительно old в виде "NAME: old -> new".
+func (r Reloadable) Diff(old Reloadable) []string {
+	var diff []string
+
+	oldV, newV := reflect.ValueOf(old), reflect.ValueOf(r)
+	for i := range newV.NumField() {
+		a, b := oldV.Field(i).Interface(), newV.Field(i).Interface()
+		if reflect.DeepEqual(a, b) {
+			continue
+		}
+		name, _, _ := strings.Cut(newV.Type().Field(i).Tag.Get("env"), ",")
+		diff = append(diff, fmt.Sprintf("%s: %v -> %v", name, a, b))
+	}
+	return diff
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/cronexpr"
+)
+
+var (
+	dedupStores              = []string{"none", "memory", "postgres"}
+	logFormats               = []string{"json", "text"}
+	kafkaSASLMechanisms      = []string{"none", "plain", "scram-sha-256", "scram-sha-512"}
+	kafkaRebalanceStrategies = []string{"range", "roundrobin"}
+	kafkaStartOffsets        = []string{"latest", "earliest"}
+	tracingExporters         = []string{"none", "otlp", "stdout"}
+	jobLogCaptureModes       = []string{"none", "failed", "all"}
+	resultSinks              = []string{"grpc", "kafka"}
+	workflowStores           = []string{"memory", "postgres"}
+	delayStores              = []string{"file", "postgres"}
+)
+
+// Validate проверяет конфигурацию целиком и возвращает все найденные
+// ошибки сразу, по одной на строку.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	// Kafka и gRPC
+	check(len(c.KafkaBrokersList) > 0, "KAFKA_BROKERS is required")
+	check(c.KafkaTopic != "", "KAFKA_TOPIC must not be empty")
+	check(len(c.KafkaTopics) == 0 || c.KafkaTopicPattern == "",
+		"KAFKA_TOPICS and KAFKA_TOPIC_PATTERN are mutually exclusive")
+	check(!slices.Contains(c.KafkaTopics, ""), "KAFKA_TOPICS must not contain empty names")
+	var topicPattern *regexp.Regexp
+	if c.KafkaTopicPattern != "" {
+		var err error
+		topicPattern, err = TopicPattern(c.KafkaTopicPattern)
+		check(err == nil, "KAFKA_TOPIC_PATTERN %q is invalid: %v", c.KafkaTopicPattern, err)
+		check(c.KafkaTopicRefreshInterval > 0,
+			"KAFKA_TOPIC_REFRESH_INTERVAL must be positive, got %s", c.KafkaTopicRefreshInterval)
+	}
+	check(c.KafkaGroupID != "", "KAFKA_GROUP_ID is required")
+	check((c.KafkaTLSCertFile == "") == (c.KafkaTLSKeyFile == ""),
+		"KAFKA_TLS_CERT_FILE and KAFKA_TLS_KEY_FILE must be set together")
+	check(c.KafkaTLSEnabled || (c.KafkaTLSCAFile == "" && c.KafkaTLSCertFile == ""),
+		"KAFKA_TLS_CA_FILE and KAFKA_TLS_CERT_FILE require KAFKA_TLS_ENABLED=true")
+	check(slices.Contains(kafkaSASLMechanisms, c.KafkaSASLMechanism),
+		"KAFKA_SASL_MECHANISM must be one of %v, got %q", kafkaSASLMechanisms, c.KafkaSASLMechanism)
+	check(c.KafkaSASLMechanism == "none" || (c.KafkaSASLUsername != "" && c.KafkaSASLPassword != ""),
+		"KAFKA_SASL_USERNAME and KAFKA_SASL_PASSWORD are required for KAFKA_SASL_MECHANISM=%s", c.KafkaSASLMechanism)
+	check(c.KafkaDialTimeout > 0, "KAFKA_DIAL_TIMEOUT must be positive, got %s", c.KafkaDialTimeout)
+	check(c.KafkaMinBytes >= 1 && c.KafkaMinBytes <= c.KafkaMaxBytes,
+		"KAFKA_MIN_BYTES must be in [1, KAFKA_MAX_BYTES=%d], got %d", c.KafkaMaxBytes, c.KafkaMinBytes)
+	check(c.KafkaMaxWait > 0, "KAFKA_MAX_WAIT must be positive, got %s", c.KafkaMaxWait)
+	check(c.KafkaSessionTimeout > 0, "KAFKA_SESSION_TIMEOUT must be positive, got %s", c.KafkaSessionTimeout)
+	check(c.KafkaHeartbeatInterval > 0 && c.KafkaHeartbeatInterval < c.KafkaSessionTimeout,
+		"KAFKA_HEARTBEAT_INTERVAL must be positive and less than KAFKA_SESSION_TIMEOUT, got %s", c.KafkaHeartbeatInterval)
+	check(c.KafkaRebalanceTimeout > 0, "KAFKA_REBALANCE_TIMEOUT must be positive, got %s", c.KafkaRebalanceTimeout)
+	check(slices.Contains(kafkaRebalanceStrategies, c.KafkaRebalanceStrategy),
+		"KAFKA_REBALANCE_STRATEGY must be one of %v, got %q", kafkaRebalanceStrategies, c.KafkaRebalanceStrategy)
+	check(slices.Contains(kafkaStartOffsets, c.KafkaStartOffset),
+		"KAFKA_START_OFFSET must be one of %v, got %q", kafkaStartOffsets, c.KafkaStartOffset)
+	check(c.GrpcServerAddress != "", "GRPC_SERVER_ADDRESS is required")
+	check(c.GrpcTimeout > 0, "GRPC_TIMEOUT must be positive, got %s", c.GrpcTimeout)
+	check((c.GrpcTLSCertFile == "") == (c.GrpcTLSKeyFile == ""),
+		"GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE must be set together")
+	check(c.GrpcTLSEnabled || (c.GrpcTLSCAFile == "" && c.GrpcTLSCertFile == "" && c.GrpcTLSServerName == ""),
+		"GRPC_TLS_CA_FILE, GRPC_TLS_CERT_FILE and GRPC_TLS_SERVER_NAME require GRPC_TLS_ENABLED=true")
+	check(c.GrpcAuthToken == "" || c.GrpcAuthTokenFile == "",
+		"GRPC_AUTH_TOKEN and GRPC_AUTH_TOKEN_FILE are mutually exclusive")
+	check(c.GrpcTLSEnabled || (c.GrpcAuthToken == "" && c.GrpcAuthTokenFile == ""),
+		"GRPC_AUTH_TOKEN requires GRPC_TLS_ENABLED=true: the token must not be sent in plaintext")
+
+	// Пул, таймауты, логи и rate limit'ы
+	if err := c.Reloadable.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	check(c.JobsChannelBuffer >= 0, "JOBS_CHANNEL_BUFFER must not be negative, got %d", c.JobsChannelBuffer)
+	check(c.ResultsChannelBuffer >= 0, "RESULTS_CHANNEL_BUFFER must not be negative, got %d", c.ResultsChannelBuffer)
+	check(c.ProgressChannelBuffer >= 0, "PROGRESS_CHANNEL_BUFFER must not be negative, got %d", c.ProgressChannelBuffer)
+	check(c.HeartbeatInterval >= 0, "HEARTBEAT_INTERVAL must not be negative, got %s", c.HeartbeatInterval)
+	check(c.RegistrationInterval >= 0, "REGISTRATION_INTERVAL must not be negative, got %s", c.RegistrationInterval)
+	check(c.ProgressInterval >= 0, "PROGRESS_INTERVAL must not be negative, got %s", c.ProgressInterval)
+
+	// Остановка
+	check(c.ShutdownConsumerTimeout > 0, "SHUTDOWN_CONSUMER_TIMEOUT must be positive, got %s", c.ShutdownConsumerTimeout)
+	check(c.ShutdownFlushTimeout > 0, "SHUTDOWN_FLUSH_TIMEOUT must be positive, got %s", c.ShutdownFlushTimeout)
+	check(c.ShutdownTimeout > c.ShutdownConsumerTimeout+c.ShutdownFlushTimeout,
+		"SHUTDOWN_TIMEOUT must exceed SHUTDOWN_CONSUMER_TIMEOUT + SHUTDOWN_FLUSH_TIMEOUT = %s, got %s",
+		c.ShutdownConsumerTimeout+c.ShutdownFlushTimeout, c.ShutdownTimeout)
+
+	// Получатели результатов
+	check(len(c.ResultSinks) > 0, "RESULT_SINKS must not be empty")
+	for i, sink := range c.ResultSinks {
+		check(slices.Contains(resultSinks, sink), "RESULT_SINKS items must be one of %v, got %q", resultSinks, sink)
+		check(!slices.Contains(c.ResultSinks[:i], sink), "RESULT_SINKS contains duplicate %q", sink)
+	}
+	if slices.Contains(c.ResultSinks, "kafka") {
+		check(c.KafkaResultsTopic != "", "KAFKA_RESULTS_TOPIC is required for RESULT_SINKS=kafka")
+		check(c.KafkaWriteTimeout > 0, "KAFKA_WRITE_TIMEOUT must be positive, got %s", c.KafkaWriteTimeout)
+	}
+
+	// Spool результатов
+	if c.ResultsSpoolDir != "" {
+		check(c.ResultsSpoolSegmentBytes > 0, "RESULTS_SPOOL_SEGMENT_BYTES must be positive, got %d", c.ResultsSpoolSegmentBytes)
+		check(c.ResultsSpoolMaxBytes >= c.ResultsSpoolSegmentBytes,
+			"RESULTS_SPOOL_MAX_BYTES must be at least RESULTS_SPOOL_SEGMENT_BYTES = %d, got %d",
+			c.ResultsSpoolSegmentBytes, c.ResultsSpoolMaxBytes)
+	}
+
+	// Circuit breakers
+	check(c.BreakerFailureThreshold >= 1, "BREAKER_FAILURE_THRESHOLD must be at least 1, got %d", c.BreakerFailureThreshold)
+	check(c.BreakerOpenTimeout > 0, "BREAKER_OPEN_TIMEOUT must be positive, got %s", c.BreakerOpenTimeout)
+	check(c.BreakerHalfOpenMaxCalls >= 1, "BREAKER_HALF_OPEN_MAX_CALLS must be at least 1, got %d", c.BreakerHalfOpenMaxCalls)
+
+	// Dedup
+	check(slices.Contains(dedupStores, c.DedupStore), "DEDUP_STORE must be one of %v, got %q", dedupStores, c.DedupStore)
+	check(c.DedupTTL > 0, "DEDUP_TTL must be positive, got %s", c.DedupTTL)
+	check(c.DedupCacheSize >= 1, "DEDUP_CACHE_SIZE must be at least 1, got %d", c.DedupCacheSize)
+	check(c.DedupStore != "postgres" || c.DedupPostgresDSN != "", "DEDUP_POSTGRES_DSN is required for DEDUP_STORE=postgres")
+
+	// Workflow
+	check(slices.Contains(workflowStores, c.WorkflowStore),
+		"WORKFLOW_STORE must be one of %v, got %q", workflowStores, c.WorkflowStore)
+	check(c.WorkflowStore != "postgres" || c.WorkflowPostgresDSN != "",
+		"WORKFLOW_POSTGRES_DSN is required for WORKFLOW_STORE=postgres")
+	check(c.WorkflowTTL > 0, "WORKFLOW_TTL must be positive, got %s", c.WorkflowTTL)
+
+	// Отложенные задачи
+	check(slices.Contains(delayStores, c.DelayStore), "DELAY_STORE must be one of %v, got %q", delayStores, c.DelayStore)
+	check(c.DelayStore != "file" || c.DelayDir != "", "DELAY_DIR is required for DELAY_STORE=file")
+	check(c.DelayStore != "postgres" || c.DelayPostgresDSN != "", "DELAY_POSTGRES_DSN is required for DELAY_STORE=postgres")
+	check(c.DelayPollInterval > 0, "DELAY_POLL_INTERVAL must be positive, got %s", c.DelayPollInterval)
+
+	// Плагины
+	check(c.PluginStartTimeout > 0, "PLUGIN_START_TIMEOUT must be positive, got %s", c.PluginStartTimeout)
+	check(c.PluginHealthInterval > 0, "PLUGIN_HEALTH_INTERVAL must be positive, got %s", c.PluginHealthInterval)
+	check(c.PluginHealthTimeout > 0, "PLUGIN_HEALTH_TIMEOUT must be positive, got %s", c.PluginHealthTimeout)
+	check(c.PluginMaxHealthFailures >= 1, "PLUGIN_MAX_HEALTH_FAILURES must be at least 1, got %d", c.PluginMaxHealthFailures)
+
+	// Прочее
+	check(c.ReloadInterval >= 0, "CONFIG_RELOAD_INTERVAL must not be negative, got %s", c.ReloadInterval)
+	check(slices.Contains(logFormats, c.LogFormat), "LOG_FORMAT must be one of %v, got %q", logFormats, c.LogFormat)
+	check(slices.Contains(jobLogCaptureModes, c.JobLogCapture),
+		"JOB_LOG_CAPTURE must be one of %v, got %q", jobLogCaptureModes, c.JobLogCapture)
+	check(c.JobLogCaptureMaxBytes >= 1024,
+		"JOB_LOG_CAPTURE_MAX_BYTES must be at least 1024, got %d", c.JobLogCaptureMaxBytes)
+	check(c.HealthPort > 0 && c.HealthPort <= 65535, "HEALTH_PORT must be in [1, 65535], got %d", c.HealthPort)
+	check(c.AdminToken == "" || (c.AdminPort > 0 && c.AdminPort <= 65535 && c.AdminPort != c.HealthPort),
+		"ADMIN_PORT must be in [1, 65535] and differ from HEALTH_PORT, got %d", c.AdminPort)
+
+	// Tracing
+	check(slices.Contains(tracingExporters, c.TracingExporter),
+		"TRACING_EXPORTER must be one of %v, got %q", tracingExporters, c.TracingExporter)
+	check(c.TracingSampleRatio >= 0 && c.TracingSampleRatio <= 1,
+		"TRACING_SAMPLE_RATIO must be in [0, 1], got %v", c.TracingSampleRatio)
+
+	// Секции типов задач, в стабильном порядке
+	if err := c.ValidateJobTypes(c.JobTypes); err != nil {
+		errs = append(errs, err)
+	}
+	names := make([]string, 0, len(c.JobTypes))
+	for name := range c.JobTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		jt := c.JobTypes[name]
+		check(jt.Concurrency >= 0, "job_types.%s.concurrency must not be negative, got %d", name, jt.Concurrency)
+	}
+
+	// Секции топиков: только для читаемых воркером топиков
+	topics := make([]string, 0, len(c.Topics))
+	for name := range c.Topics {
+		topics = append(topics, name)
+	}
+	sort.Strings(topics)
+	for _, name := range topics {
+		tc := c.Topics[name]
+		if topicPattern != nil {
+			check(topicPattern.MatchString(name), "topics.%s does not match KAFKA_TOPIC_PATTERN %q", name, c.KafkaTopicPattern)
+		} else if c.KafkaTopicPattern == "" {
+			check(slices.Contains(c.ConsumerTopics(), name), "topics.%s is not consumed, topics: %v", name, c.ConsumerTopics())
+		}
+		check(tc.Concurrency >= 0, "topics.%s.concurrency must not be negative, got %d", name, tc.Concurrency)
+		check(!slices.Contains(tc.JobTypes, ""), "topics.%s.job_types must not contain empty names", name)
+	}
+
+	// Задачи по расписанию
+	cronNames := make(map[string]bool, len(c.Cron))
+	for i, job := range c.Cron {
+		check(job.Name != "" && !strings.Contains(job.Name, "/"),
+			"cron[%d].name must be non-empty and must not contain '/', got %q", i, job.Name)
+		check(!cronNames[job.Name], "cron[%d].name %q is duplicated", i, job.Name)
+		cronNames[job.Name] = true
+		check(job.Type != "", "cron.%s.type is required", job.Name)
+		if _, err := cronexpr.Parse(job.Schedule); err != nil {
+			check(false, "cron.%s.schedule %q is invalid: %v", job.Name, job.Schedule, err)
+		}
+		if job.Timezone != "" {
+			_, err := time.LoadLocation(job.Timezone)
+			check(err == nil, "cron.%s.timezone %q is unknown", job.Name, job.Timezone)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return nil
+}
+
+// TopicPattern компилирует KAFKA_TOPIC_PATTERN так, чтобы с ним совпадали
+// только имена топиков целиком.
+func TopicPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
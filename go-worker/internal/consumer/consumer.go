@@ -1,8 +1,27 @@
 package consumer
 
-import "context"
+import (
+	"context"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
 
 type Consumer interface {
 	Start(ctx context.Context) error
 	Close() error
+
+	// Pause останавливает чтение новых сообщений до Resume.
+	// Уже прочитанное сообщение будет обработано.
+	Pause()
+	Resume()
+	Paused() bool
+
+	// Drain ставит чтение на паузу и ждет, пока уже прочитанное
+	// сообщение будет передано в пул и закоммичено.
+	Drain(ctx context.Context) error
+}
+
+// Delayer откладывает задачу, время выполнения которой (NotBefore) еще не наступило.
+type Delayer interface {
+	Delay(ctx context.Context, job models.Job) error
 }
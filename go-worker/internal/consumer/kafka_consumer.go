@@ -5,73 +5,169 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"strings"
+	"sync"
+	"time"
 
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
 	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/kafkaconn"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 )
 
-const MaxMessageBytes = 10e6 // 10MB
+// Задержка повтора, если отложенную задачу не удалось сохранить
+const (
+	delayRetryMin = time.Second
+	delayRetryMax = 30 * time.Second
+)
 
 type kafkaConsumer struct {
-	jobChan chan<- models.Job
-	reader  *kafka.Reader
+	cfg      *config.Config
+	dialer   *kafka.Dialer
+	resolver *topicResolver
+	jobChan  chan<- models.Job
+	delayer  Delayer
+
+	// Пауза: Pause прерывает текущий FetchMessage, цикл чтения закрывает
+	// parked, когда остановился, а Resume закрывает resumed
+	mu          sync.Mutex
+	paused      bool
+	resumed     chan struct{}
+	parked      chan struct{}
+	cancelFetch context.CancelFunc
+	stopped     chan struct{} // Закрывается при выходе из Start
+
+	// Reader пересоздается, когда меняется набор топиков по KAFKA_TOPIC_PATTERN
+	reader        *kafka.Reader
+	topics        []string
+	pendingTopics []string // Новый набор топиков, применяется циклом чтения
+	closed        bool
 }
 
-func NewKafkaConsumer(cfg *config.Config, jobChan chan<- models.Job) Consumer {
-	brokers := strings.Split(cfg.KafkaBrokers, ",")
-	for i := range brokers {
-		brokers[i] = strings.TrimSpace(brokers[i])
+// NewKafkaConsumer создает consumer, который читает все топики из
+// KAFKA_TOPICS (KAFKA_TOPIC) или совпадающие с KAFKA_TOPIC_PATTERN
+// одной consumer group.
+func NewKafkaConsumer(
+	cfg *config.Config,
+	jobChan chan<- models.Job,
+	delayer Delayer,
+	ctx context.Context,
+) (Consumer, error) {
+	dialer, err := kafkaconn.Dialer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	resolver, err := newTopicResolver(cfg)
+	if err != nil {
+		return nil, err
+	}
+	topics, err := resolver.resolve(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:        brokers,
-		Topic:          cfg.KafkaTopic,
-		GroupID:        cfg.KafkaGroupID,
-		MinBytes:       1,
-		MaxBytes:       MaxMessageBytes,
-		CommitInterval: 0,
-		StartOffset:    kafka.LastOffset,
-	})
+	kc := &kafkaConsumer{
+		cfg:      cfg,
+		dialer:   dialer,
+		resolver: resolver,
+		jobChan:  jobChan,
+		delayer:  delayer,
+		topics:   topics,
+		stopped:  make(chan struct{}),
+	}
+	kc.reader = kafka.NewReader(kc.readerConfig(topics))
+	consumedTopics.Set(float64(len(topics)))
+	return kc, nil
+}
 
-	return &kafkaConsumer{
-		jobChan: jobChan,
-		reader:  reader,
+func (kc *kafkaConsumer) readerConfig(topics []string) kafka.ReaderConfig {
+	cfg := kc.cfg
+	return kafka.ReaderConfig{
+		Brokers:           cfg.KafkaBrokersList,
+		GroupTopics:       topics,
+		GroupID:           cfg.KafkaGroupID,
+		Dialer:            kc.dialer,
+		MinBytes:          cfg.KafkaMinBytes,
+		MaxBytes:          cfg.KafkaMaxBytes,
+		MaxWait:           cfg.KafkaMaxWait,
+		SessionTimeout:    cfg.KafkaSessionTimeout,
+		HeartbeatInterval: cfg.KafkaHeartbeatInterval,
+		RebalanceTimeout:  cfg.KafkaRebalanceTimeout,
+		GroupBalancers:    kafkaconn.GroupBalancers(cfg),
+		CommitInterval:    0,
+		StartOffset:       kafkaconn.StartOffset(cfg),
 	}
 }
 
 func (kc *kafkaConsumer) Start(ctx context.Context) error {
+	defer close(kc.stopped)
+
 	slog.Info("Kafka consumer started",
-		slog.String("topic", kc.reader.Config().Topic),
-		slog.String("group", kc.reader.Config().GroupID),
+		slog.Any("topics", kc.topics),
+		slog.String("group", kc.cfg.KafkaGroupID),
 	)
 
+	if kc.resolver.dynamic() {
+		var wg sync.WaitGroup
+		watchCtx, cancel := context.WithCancel(ctx)
+		defer wg.Wait()
+		defer cancel()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			kc.watchTopics(watchCtx, kc.cfg.KafkaTopicRefreshInterval)
+		}()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			slog.Info("Kafka consumer stopped by context")
 			return ctx.Err()
 		default:
-			msg, err := kc.reader.FetchMessage(ctx)
+			if err := kc.waitResumed(ctx); err != nil {
+				return nil
+			}
+			if !kc.resubscribe() {
+				return nil
+			}
+
+			fetchCtx, cancel := kc.fetchContext(ctx)
+			reader := kc.currentReader()
+			msg, err := reader.FetchMessage(fetchCtx)
+			cancel()
 			if err != nil {
-				if errors.Is(err, context.Canceled) {
+				switch {
+				case ctx.Err() != nil:
 					return nil
+				case errors.Is(err, context.Canceled):
+					// Чтение прервано паузой или сменой топиков, сообщение останется в reader'е
+					continue
 				}
 				return fmt.Errorf("kafka fetch error: %w", err)
 			}
+			messagesConsumed.WithLabelValues(msg.Topic).Inc()
+
+			// Прочитанное сообщение обрабатывается и коммитится даже после паузы
 			if err := kc.processMessage(ctx, msg); err != nil {
+				messagesFailed.WithLabelValues(msg.Topic).Inc()
 				slog.Error("Failed to process message",
 					"error", err,
+					"topic", msg.Topic,
 					"offset", msg.Offset,
 					"partition", msg.Partition,
 				)
 				continue
 			}
-			if err := kc.reader.CommitMessages(ctx, msg); err != nil {
+			if err := reader.CommitMessages(ctx, msg); err != nil {
 				slog.Error("Failed to commit offset", "error", err)
 			}
 		}
@@ -79,20 +175,147 @@ func (kc *kafkaConsumer) Start(ctx context.Context) error {
 }
 
 func (kc *kafkaConsumer) Close() error {
-	return kc.reader.Close()
+	kc.mu.Lock()
+	kc.closed = true
+	reader := kc.reader
+	kc.mu.Unlock()
+
+	return reader.Close()
+}
+
+func (kc *kafkaConsumer) currentReader() *kafka.Reader {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	return kc.reader
+}
+
+func (kc *kafkaConsumer) Pause() {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	if kc.paused {
+		return
+	}
+	kc.paused = true
+	kc.resumed = make(chan struct{})
+	kc.parked = make(chan struct{})
+	if kc.cancelFetch != nil {
+		kc.cancelFetch()
+	}
+	slog.Info("Kafka consumer paused")
+}
+
+func (kc *kafkaConsumer) Resume() {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	if kc.paused {
+		kc.paused = false
+		close(kc.resumed)
+		slog.Info("Kafka consumer resumed")
+	}
 }
 
-func (kc *kafkaConsumer) processMessage(ctx context.Context, msg kafka.Message) error {
+func (kc *kafkaConsumer) Paused() bool {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	return kc.paused
+}
+
+// Drain ставит consumer на паузу и ждет, пока цикл чтения передаст в пул
+// и закоммитит уже прочитанное сообщение.
+func (kc *kafkaConsumer) Drain(ctx context.Context) error {
+	kc.Pause()
+
+	kc.mu.Lock()
+	parked := kc.parked
+	kc.mu.Unlock()
+
+	select {
+	case <-parked:
+	case <-kc.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	slog.Info("Kafka consumer drained")
+	return nil
+}
+
+// fetchContext возвращает контекст чтения, который отменяется при Pause.
+func (kc *kafkaConsumer) fetchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	fetchCtx, cancel := context.WithCancel(ctx)
+
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	if kc.paused {
+		cancel()
+	} else {
+		kc.cancelFetch = cancel
+	}
+	return fetchCtx, cancel
+}
+
+// waitResumed блокируется, пока consumer на паузе. Reader продолжает
+// heartbeat'ы группы в фоне, поэтому пауза не вызывает ребалансировку.
+func (kc *kafkaConsumer) waitResumed(ctx context.Context) error {
+	kc.mu.Lock()
+	paused, resumed, parked := kc.paused, kc.resumed, kc.parked
+	kc.cancelFetch = nil
+	if paused {
+		select {
+		case <-parked:
+		default:
+			close(parked)
+		}
+	}
+	kc.mu.Unlock()
+
+	if !paused {
+		return nil
+	}
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (kc *kafkaConsumer) processMessage(ctx context.Context, msg kafka.Message) (err error) {
+	// Продолжаем трассу, начатую при создании задачи в Java сервисе
+	parent := otel.GetTextMapPropagator().Extract(ctx, (*tracing.KafkaHeaderCarrier)(&msg.Headers))
+	spanCtx, span := tracing.Tracer().Start(parent, "job.receive",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", msg.Topic),
+			attribute.Int("messaging.kafka.partition", msg.Partition),
+			attribute.Int64("messaging.kafka.offset", msg.Offset),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	var jobTask pb.JobTask
 	if err := proto.Unmarshal(msg.Value, &jobTask); err != nil {
 		return fmt.Errorf("failed to unmarshal protobuf: %w", err)
 	}
+	span.SetAttributes(tracing.AttrJobID.Int64(jobTask.GetJobId()))
 	slog.Debug("Received job from Kafka",
 		slog.Int64("job_id", jobTask.GetJobId()),
 		slog.String("type", jobTask.GetType().String()),
+		slog.String("type_name", jobTask.GetTypeName()),
 	)
 
-	jobType, err := jobregistry.JobTypeFromProto(jobTask.GetType())
+	jobType, err := jobregistry.ResolveJobType(jobTask.GetType(), jobTask.GetTypeName())
 	if err != nil {
 		return fmt.Errorf("unknown task type: %w", err)
 	}
@@ -102,6 +325,26 @@ func (kc *kafkaConsumer) processMessage(ctx context.Context, msg kafka.Message)
 		Type:      jobType,
 		Payload:   jobTask.GetPayload(),
 		CreatedAt: jobTask.GetCreatedAt(),
+		Attempt:   int(jobTask.GetAttempt()),
+
+		TraceHeaders: tracing.Inject(spanCtx),
+		ReceivedAt:   time.Now(),
+		Topic:        msg.Topic,
+		Partition:    msg.Partition,
+		Offset:       msg.Offset,
+	}
+	if step := jobTask.GetWorkflowStep(); step != nil {
+		job.Workflow = &models.WorkflowStepRef{Step: step.GetStep(), Run: int(step.GetRun())}
+	}
+	if notBefore := jobTask.GetNotBefore(); notBefore > 0 {
+		job.NotBefore = time.Unix(notBefore, 0)
+	}
+	span.SetAttributes(tracing.AttrJobType.String(string(jobType)))
+
+	// Задача, время которой не наступило, ждет в хранилище, а не в пуле
+	if kc.delayer != nil && job.NotBefore.After(time.Now()) {
+		span.SetAttributes(attribute.String("job.not_before", job.NotBefore.Format(time.RFC3339)))
+		return kc.delay(ctx, job)
 	}
 
 	// Отправка в Worker Pool через канал
@@ -112,3 +355,29 @@ func (kc *kafkaConsumer) processMessage(ctx context.Context, msg kafka.Message)
 		return ctx.Err()
 	}
 }
+
+// delay сохраняет задачу в хранилище отложенных задач. Ошибка хранилища (диск,
+// БД) временная, а сообщение нельзя коммитить, пока задача не сохранена, и нельзя
+// пропускать: попытка повторяется с экспоненциальной задержкой, чтение на это
+// время останавливается. После отмены ctx сообщение остается незакоммиченным.
+func (kc *kafkaConsumer) delay(ctx context.Context, job models.Job) error {
+	backoff := delayRetryMin
+	for {
+		err := kc.delayer.Delay(ctx, job)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		slog.Warn("Failed to delay job, retrying",
+			slog.Int64("job_id", job.ID),
+			slog.String("error", err.Error()),
+			slog.Duration("retry_in", backoff),
+		)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff = min(backoff*2, delayRetryMax)
+	}
+}
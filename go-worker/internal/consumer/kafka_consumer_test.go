@@ -0,0 +1,187 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/executor"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProcessMessagePassesReceiveSpanToJob(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	// Продюсер кладет traceparent в заголовки сообщения
+	producerCtx, producer := tracing.Tracer().Start(context.Background(), "producer")
+	producer.End()
+	value, err := proto.Marshal(&pb.JobTask{JobId: 1, Type: pb.JobTask_SLEEP})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := kafka.Message{Topic: "job_requests", Value: value}
+	otel.GetTextMapPropagator().Inject(producerCtx, (*tracing.KafkaHeaderCarrier)(&msg.Headers))
+
+	jobs := make(chan models.Job, 1)
+	kc := &kafkaConsumer{jobChan: jobs}
+	if err := kc.processMessage(context.Background(), msg); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+	job := <-jobs
+
+	var receive sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "job.receive" {
+			receive = s
+		}
+	}
+	if receive == nil {
+		t.Fatal("job.receive span not recorded")
+	}
+	if receive.Parent().SpanID() != producer.SpanContext().SpanID() {
+		t.Errorf("job.receive must continue producer trace, parent %s", receive.Parent().SpanID())
+	}
+
+	// Пул и отправитель результатов продолжают трассу от спана job.receive
+	got := trace.SpanContextFromContext(tracing.Extract(context.Background(), job.TraceHeaders))
+	if got.SpanID() != receive.SpanContext().SpanID() {
+		t.Errorf("job carries span %s, want job.receive %s", got.SpanID(), receive.SpanContext().SpanID())
+	}
+}
+
+func TestPauseParksReadLoopUntilResume(t *testing.T) {
+	kc := &kafkaConsumer{stopped: make(chan struct{})}
+
+	// Чтение, начатое до паузы, прерывается
+	fetchCtx, cancel := kc.fetchContext(context.Background())
+	defer cancel()
+	kc.Pause()
+	if fetchCtx.Err() == nil {
+		t.Fatal("pause must cancel current fetch")
+	}
+	if !kc.Paused() {
+		t.Fatal("expected consumer to be paused")
+	}
+
+	drained := make(chan error, 1)
+	go func() { drained <- kc.Drain(context.Background()) }()
+	select {
+	case err := <-drained:
+		t.Fatalf("drain must wait for read loop to park, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Цикл чтения останавливается на waitResumed
+	resumed := make(chan error, 1)
+	go func() { resumed <- kc.waitResumed(context.Background()) }()
+	select {
+	case err := <-drained:
+		if err != nil {
+			t.Fatalf("drain: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("drain did not finish after read loop parked")
+	}
+
+	// Новое чтение на паузе сразу отменено
+	pausedCtx, cancelPaused := kc.fetchContext(context.Background())
+	defer cancelPaused()
+	if pausedCtx.Err() == nil {
+		t.Error("fetch context must be cancelled while paused")
+	}
+
+	select {
+	case err := <-resumed:
+		t.Fatalf("read loop must stay parked until resume, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	kc.Resume()
+	select {
+	case err := <-resumed:
+		if err != nil {
+			t.Fatalf("wait resumed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("read loop was not resumed")
+	}
+	if kc.Paused() {
+		t.Error("expected consumer to be resumed")
+	}
+}
+
+func TestDrainStopsWaitingOnContext(t *testing.T) {
+	kc := &kafkaConsumer{stopped: make(chan struct{})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := kc.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+
+	// Остановленный consumer считается выведенным из работы
+	close(kc.stopped)
+	if err := kc.Drain(context.Background()); err != nil {
+		t.Fatalf("drain of stopped consumer: %v", err)
+	}
+}
+
+// flakyDelayer не может сохранить задачу с первого раза.
+type flakyDelayer struct {
+	failures int
+	delayed  []models.Job
+}
+
+func (d *flakyDelayer) Delay(_ context.Context, job models.Job) error {
+	if d.failures > 0 {
+		d.failures--
+		return errors.New("disk full")
+	}
+	d.delayed = append(d.delayed, job)
+	return nil
+}
+
+func TestProcessMessageRetriesDelay(t *testing.T) {
+	value, err := proto.Marshal(&pb.JobTask{JobId: 1, Type: pb.JobTask_SLEEP, NotBefore: time.Now().Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs := make(chan models.Job, 1)
+	delayer := &flakyDelayer{failures: 1}
+	kc := &kafkaConsumer{jobChan: jobs, delayer: delayer}
+
+	if err := kc.processMessage(context.Background(), kafka.Message{Value: value}); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+	if len(delayer.delayed) != 1 || delayer.delayed[0].ID != 1 {
+		t.Fatalf("expected job delayed after retry, got %+v", delayer.delayed)
+	}
+	if len(jobs) != 0 {
+		t.Error("delayed job must not be passed to the pool")
+	}
+
+	// Остановка прерывает повторы, сообщение не коммитится
+	delayer.failures = 1
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := kc.processMessage(ctx, kafka.Message{Value: value}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package consumer
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	messagesConsumed = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "consumer",
+		Name:      "messages_total",
+		Help:      "Kafka messages read by the consumer, by topic.",
+	}, []string{"topic"})
+
+	messagesFailed = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "consumer",
+		Name:      "failed_messages_total",
+		Help:      "Kafka messages that could not be turned into jobs, by topic.",
+	}, []string{"topic"})
+
+	consumedTopics = promauto.With(metrics.Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "consumer",
+		Name:      "topics",
+		Help:      "Number of Kafka topics the consumer is subscribed to.",
+	})
+)
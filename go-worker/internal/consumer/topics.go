@@ -0,0 +1,150 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/kafkaconn"
+	"github.com/segmentio/kafka-go"
+)
+
+// metadataClient запрашивает метаданные кластера (*kafka.Client).
+type metadataClient interface {
+	Metadata(ctx context.Context, req *kafka.MetadataRequest) (*kafka.MetadataResponse, error)
+}
+
+// topicResolver определяет топики для чтения: список из конфигурации
+// или топики кластера, имена которых совпадают с KAFKA_TOPIC_PATTERN.
+type topicResolver struct {
+	topics  []string       // Заданы списком (KAFKA_TOPICS или KAFKA_TOPIC)
+	pattern *regexp.Regexp // KAFKA_TOPIC_PATTERN, совпадает с именем целиком
+	client  metadataClient
+	timeout time.Duration
+}
+
+func newTopicResolver(cfg *config.Config) (*topicResolver, error) {
+	if cfg.KafkaTopicPattern == "" {
+		return &topicResolver{topics: cfg.ConsumerTopics()}, nil
+	}
+
+	pattern, err := config.TopicPattern(cfg.KafkaTopicPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KAFKA_TOPIC_PATTERN: %w", err)
+	}
+	transport, err := kafkaconn.Transport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &topicResolver{
+		pattern: pattern,
+		client: &kafka.Client{
+			Addr:      kafka.TCP(cfg.KafkaBrokersList...),
+			Transport: transport,
+		},
+		timeout: cfg.KafkaDialTimeout,
+	}, nil
+}
+
+// dynamic сообщает, что набор топиков может меняться и его нужно перечитывать.
+func (r *topicResolver) dynamic() bool {
+	return r.pattern != nil
+}
+
+// resolve возвращает отсортированный список топиков для чтения.
+func (r *topicResolver) resolve(ctx context.Context) ([]string, error) {
+	if r.pattern == nil {
+		return r.topics, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	meta, err := r.client.Metadata(ctx, &kafka.MetadataRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kafka topics: %w", err)
+	}
+
+	var topics []string
+	for _, t := range meta.Topics {
+		if t.Internal || t.Error != nil || !r.pattern.MatchString(t.Name) {
+			continue
+		}
+		topics = append(topics, t.Name)
+	}
+	if len(topics) == 0 {
+		return nil, errors.New("no kafka topics match KAFKA_TOPIC_PATTERN " + r.pattern.String())
+	}
+	slices.Sort(topics)
+	return topics, nil
+}
+
+// watchTopics раз в interval перечитывает топики по KAFKA_TOPIC_PATTERN
+// и, если набор изменился, переподписывает consumer.
+func (kc *kafkaConsumer) watchTopics(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		topics, err := kc.resolver.resolve(ctx)
+		if err != nil {
+			// Текущая подписка сохраняется: топики могли быть недоступны временно
+			if ctx.Err() == nil {
+				slog.Warn("Failed to refresh kafka topics", slog.String("error", err.Error()))
+			}
+			continue
+		}
+
+		kc.mu.Lock()
+		if !slices.Equal(topics, kc.topics) {
+			slog.Info("Kafka topics changed, resubscribing",
+				slog.Any("old", kc.topics),
+				slog.Any("new", topics),
+			)
+			kc.pendingTopics = topics
+			if kc.cancelFetch != nil {
+				kc.cancelFetch()
+			}
+		}
+		kc.mu.Unlock()
+	}
+}
+
+// resubscribe пересоздает reader с новым набором топиков. Прочитанные
+// сообщения уже закоммичены, поэтому закрытие reader'а ничего не теряет.
+// Возвращает false, если consumer закрыт.
+func (kc *kafkaConsumer) resubscribe() bool {
+	kc.mu.Lock()
+	topics, old := kc.pendingTopics, kc.reader
+	kc.pendingTopics = nil
+	kc.mu.Unlock()
+
+	if topics == nil {
+		return true
+	}
+	if err := old.Close(); err != nil {
+		slog.Warn("Failed to close kafka reader", slog.String("error", err.Error()))
+	}
+
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	if kc.closed {
+		return false
+	}
+	kc.topics = topics
+	kc.reader = kafka.NewReader(kc.readerConfig(topics))
+	consumedTopics.Set(float64(len(topics)))
+	return true
+}
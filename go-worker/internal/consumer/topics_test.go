@@ -0,0 +1,178 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/segmentio/kafka-go"
+)
+
+// fakeMetadata отдает заданный список топиков кластера.
+type fakeMetadata struct {
+	mu     sync.Mutex
+	topics []kafka.Topic
+	err    error
+}
+
+func (m *fakeMetadata) Metadata(context.Context, *kafka.MetadataRequest) (*kafka.MetadataResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &kafka.MetadataResponse{Topics: slices.Clone(m.topics)}, nil
+}
+
+func (m *fakeMetadata) set(names ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.topics = nil
+	for _, name := range names {
+		m.topics = append(m.topics, kafka.Topic{Name: name})
+	}
+}
+
+func patternResolver(t *testing.T, pattern string, client metadataClient) *topicResolver {
+	t.Helper()
+	re, err := config.TopicPattern(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &topicResolver{pattern: re, client: client, timeout: time.Second}
+}
+
+func TestTopicResolverStaticTopics(t *testing.T) {
+	r, err := newTopicResolver(&config.Config{KafkaTopics: []string{"jobs.b", "jobs.a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.dynamic() {
+		t.Error("resolver without pattern must not be dynamic")
+	}
+	topics, err := r.resolve(context.Background())
+	if err != nil || !slices.Equal(topics, []string{"jobs.b", "jobs.a"}) {
+		t.Errorf("unexpected topics %v, err %v", topics, err)
+	}
+}
+
+func TestTopicResolverMatchesWholeName(t *testing.T) {
+	meta := &fakeMetadata{}
+	meta.set("jobs.b", "jobs.a.dlq", "jobs.a", "xjobs.c", "jobs.cc")
+	meta.topics = append(meta.topics,
+		kafka.Topic{Name: "jobs.d", Internal: true},
+		kafka.Topic{Name: "jobs.e", Error: errors.New("leader not available")},
+	)
+	// Первая альтернатива совпадает с началом jobs.a.dlq: имя все равно подходит целиком по второй
+	r := patternResolver(t, `jobs\.[a-e]|jobs\.a\.dlq`, meta)
+	if !r.dynamic() {
+		t.Error("resolver with pattern must be dynamic")
+	}
+
+	topics, err := r.resolve(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"jobs.a", "jobs.a.dlq", "jobs.b"}; !slices.Equal(topics, want) {
+		t.Errorf("topics %v, want %v", topics, want)
+	}
+
+	meta.set("other")
+	if _, err := r.resolve(context.Background()); err == nil {
+		t.Error("expected error when no topics match")
+	}
+	meta.err = errors.New("broker unavailable")
+	if _, err := r.resolve(context.Background()); err == nil {
+		t.Error("expected metadata error")
+	}
+}
+
+func TestWatchTopicsRequestsResubscribe(t *testing.T) {
+	meta := &fakeMetadata{}
+	meta.set("jobs.a")
+	kc := &kafkaConsumer{
+		resolver: patternResolver(t, `jobs\..*`, meta),
+		topics:   []string{"jobs.a"},
+	}
+	fetchCtx, cancelFetch := kc.fetchContext(context.Background())
+	defer cancelFetch()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		kc.watchTopics(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Набор топиков не изменился: чтение продолжается
+	time.Sleep(50 * time.Millisecond)
+	if fetchCtx.Err() != nil {
+		t.Fatal("fetch must not be cancelled while topics are unchanged")
+	}
+
+	meta.set("jobs.b", "jobs.a")
+	select {
+	case <-fetchCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("fetch was not cancelled after topics changed")
+	}
+	kc.mu.Lock()
+	pending := kc.pendingTopics
+	kc.mu.Unlock()
+	if !slices.Equal(pending, []string{"jobs.a", "jobs.b"}) {
+		t.Errorf("pending topics %v", pending)
+	}
+}
+
+func TestResubscribeSwapsReader(t *testing.T) {
+	kc := &kafkaConsumer{
+		cfg: &config.Config{
+			KafkaBrokersList: []string{"127.0.0.1:1"},
+			KafkaGroupID:     "test",
+		},
+		topics: []string{"jobs.a"},
+	}
+	kc.reader = kafka.NewReader(kc.readerConfig(kc.topics))
+	defer func() { _ = kc.currentReader().Close() }()
+
+	// Без нового набора топиков reader не пересоздается
+	old := kc.currentReader()
+	if !kc.resubscribe() || kc.currentReader() != old {
+		t.Fatal("reader must be kept without pending topics")
+	}
+
+	kc.pendingTopics = []string{"jobs.a", "jobs.b"}
+	if !kc.resubscribe() {
+		t.Fatal("resubscribe of open consumer must succeed")
+	}
+	if kc.currentReader() == old {
+		t.Error("reader must be recreated")
+	}
+	if got := kc.currentReader().Config().GroupTopics; !slices.Equal(got, []string{"jobs.a", "jobs.b"}) {
+		t.Errorf("reader topics %v", got)
+	}
+	if !slices.Equal(kc.topics, []string{"jobs.a", "jobs.b"}) || kc.pendingTopics != nil {
+		t.Errorf("topics %v, pending %v", kc.topics, kc.pendingTopics)
+	}
+
+	// Закрытый consumer не создает новый reader
+	if err := kc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	current := kc.currentReader()
+	kc.pendingTopics = []string{"jobs.c"}
+	if kc.resubscribe() {
+		t.Error("resubscribe of closed consumer must report false")
+	}
+	if kc.currentReader() != current {
+		t.Error("closed consumer must keep its reader")
+	}
+}
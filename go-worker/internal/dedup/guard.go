@@ -0,0 +1,84 @@
+package dedup
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+const storeTimeout = 5 * time.Second
+
+// Guard подавляет повторное выполнение задач, пришедших повторно
+// (например, после ребалансировки Kafka).
+type Guard struct {
+	store       Store
+	owner       string
+	claimMargin time.Duration
+	resultTTL   time.Duration
+}
+
+func NewGuard(cfg *config.Config, store Store) *Guard {
+	return &Guard{
+		store:       store,
+		owner:       cfg.WorkerID,
+		claimMargin: cfg.GrpcTimeout + time.Minute,
+		resultTTL:   cfg.DedupTTL,
+	}
+}
+
+// Begin пытается захватить задачу, которая будет выполняться не дольше jobTimeout
+// (MAX_JOB_TIMEOUT на момент начала задачи). При недоступности хранилища
+// задача выполняется как обычно (fail-open), чтобы не терять работу.
+func (g *Guard) Begin(ctx context.Context, key string, jobTimeout time.Duration) Claim {
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+
+	// Захват живет дольше выполнения задачи, чтобы не истечь у живого воркера
+	claim, err := g.store.Claim(ctx, key, g.owner, jobTimeout+g.claimMargin)
+	if err != nil {
+		slog.Warn("Dedup store unavailable, executing job anyway",
+			slog.String("job_key", key),
+			slog.String("error", err.Error()),
+		)
+		claimsTotal.WithLabelValues("error").Inc()
+		return Claim{Outcome: OutcomeAcquired, Owner: g.owner}
+	}
+
+	claimsTotal.WithLabelValues(claim.Outcome.String()).Inc()
+	return claim
+}
+
+// Finish сохраняет результат задачи. Временные ошибки не кэшируются,
+// чтобы повторная доставка могла выполнить задачу заново.
+func (g *Guard) Finish(key string, result models.JobResult) {
+	if result.Retryable {
+		g.Abort(key)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := g.store.Complete(ctx, key, g.owner, result, g.resultTTL); err != nil {
+		slog.Warn("Failed to store job result for dedup",
+			slog.String("job_key", key),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// Abort снимает захват, не сохраняя результат.
+func (g *Guard) Abort(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := g.store.Release(ctx, key, g.owner); err != nil {
+		slog.Warn("Failed to release dedup claim",
+			slog.String("job_key", key),
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,123 @@
+package dedup
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+type memoryEntry struct {
+	key       string
+	owner     string
+	completed bool
+	result    models.JobResult
+	expiresAt time.Time
+}
+
+// MemoryStore — LRU в памяти процесса. Защищает только от повторов,
+// пришедших на этот же воркер.
+type MemoryStore struct {
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List // Front — самые свежие
+	entries map[string]*list.Element
+}
+
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (s *MemoryStore) Claim(_ context.Context, key, owner string, ttl time.Duration) (Claim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if el, ok := s.entries[key]; ok {
+		e := el.Value.(*memoryEntry) //nolint:errcheck // в списке только *memoryEntry
+		if now.Before(e.expiresAt) {
+			s.order.MoveToFront(el)
+			if e.completed {
+				return Claim{Outcome: OutcomeCompleted, Owner: e.owner, Result: e.result}, nil
+			}
+			return Claim{Outcome: OutcomeRunning, Owner: e.owner}, nil
+		}
+		s.remove(el)
+	}
+
+	s.entries[key] = s.order.PushFront(&memoryEntry{
+		key:       key,
+		owner:     owner,
+		expiresAt: now.Add(ttl),
+	})
+	s.trim()
+
+	return Claim{Outcome: OutcomeAcquired, Owner: owner}, nil
+}
+
+func (s *MemoryStore) Complete(
+	_ context.Context,
+	key, owner string,
+	result models.JobResult,
+	ttl time.Duration,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		// Запись успели вытеснить — сохраняем заново
+		el = s.order.PushFront(&memoryEntry{key: key})
+		s.entries[key] = el
+		s.trim()
+	}
+	e := el.Value.(*memoryEntry) //nolint:errcheck // в списке только *memoryEntry
+	if e.owner != "" && e.owner != owner {
+		return nil
+	}
+	e.owner = owner
+	e.completed = true
+	e.result = result
+	e.expiresAt = time.Now().Add(ttl)
+	s.order.MoveToFront(el)
+
+	return nil
+}
+
+func (s *MemoryStore) Release(_ context.Context, key, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		e := el.Value.(*memoryEntry) //nolint:errcheck // в списке только *memoryEntry
+		if e.owner == owner && !e.completed {
+			s.remove(el)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// trim вытесняет самые старые записи сверх DEDUP_CACHE_SIZE. Вызывается под s.mu.
+func (s *MemoryStore) trim() {
+	for s.order.Len() > s.capacity {
+		s.remove(s.order.Back())
+	}
+}
+
+// remove вызывается под s.mu.
+func (s *MemoryStore) remove(el *list.Element) {
+	e := el.Value.(*memoryEntry) //nolint:errcheck // в списке только *memoryEntry
+	delete(s.entries, e.key)
+	s.order.Remove(el)
+}
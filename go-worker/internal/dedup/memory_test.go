@@ -0,0 +1,68 @@
+package dedup_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/dedup"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+func TestMemoryStoreClaimLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := dedup.NewMemoryStore(10)
+
+	claim, _ := s.Claim(ctx, "1", "worker-a", time.Minute)
+	if claim.Outcome != dedup.OutcomeAcquired {
+		t.Fatalf("expected acquired, got %s", claim.Outcome)
+	}
+
+	claim, _ = s.Claim(ctx, "1", "worker-b", time.Minute)
+	if claim.Outcome != dedup.OutcomeRunning || claim.Owner != "worker-a" {
+		t.Fatalf("expected running by worker-a, got %+v", claim)
+	}
+
+	result := models.JobResult{JobID: 1, Status: models.StatusCompleted, Result: json.RawMessage(`"ok"`)}
+	if err := s.Complete(ctx, "1", "worker-a", result, time.Minute); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	claim, _ = s.Claim(ctx, "1", "worker-b", time.Minute)
+	if claim.Outcome != dedup.OutcomeCompleted || string(claim.Result.Result) != `"ok"` {
+		t.Fatalf("expected cached result, got %+v", claim)
+	}
+}
+
+func TestMemoryStoreExpiryAndEviction(t *testing.T) {
+	ctx := context.Background()
+	s := dedup.NewMemoryStore(1)
+
+	s.Claim(ctx, "1", "a", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if claim, _ := s.Claim(ctx, "1", "b", time.Minute); claim.Outcome != dedup.OutcomeAcquired {
+		t.Fatalf("expired claim must be taken over, got %s", claim.Outcome)
+	}
+
+	s.Claim(ctx, "2", "b", time.Minute) // вытесняет "1"
+	if claim, _ := s.Claim(ctx, "1", "c", time.Minute); claim.Outcome != dedup.OutcomeAcquired {
+		t.Fatalf("evicted key must be claimable, got %s", claim.Outcome)
+	}
+}
+
+func TestMemoryStoreCompleteRespectsCapacity(t *testing.T) {
+	ctx := context.Background()
+	s := dedup.NewMemoryStore(1)
+
+	s.Claim(ctx, "1", "a", time.Minute)
+	s.Claim(ctx, "2", "a", time.Minute) // вытесняет "1"
+
+	// Результат вытесненной записи сохраняется заново и вытесняет "2"
+	if err := s.Complete(ctx, "1", "a", models.JobResult{JobID: 1}, time.Minute); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if claim, _ := s.Claim(ctx, "2", "b", time.Minute); claim.Outcome != dedup.OutcomeAcquired {
+		t.Fatalf("cache must not grow beyond its capacity, got %s for evicted key", claim.Outcome)
+	}
+}
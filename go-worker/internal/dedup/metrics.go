@@ -0,0 +1,14 @@
+package dedup
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var claimsTotal = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "dedup",
+	Name:      "claims_total",
+	Help:      "Dedup claims by outcome: acquired, running, completed or error.",
+}, []string{"outcome"})
@@ -0,0 +1,121 @@
+package dedup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	_ "github.com/jackc/pgx/v5/stdlib" // драйвер "pgx" для database/sql
+)
+
+const (
+	createTableQuery = `
+CREATE TABLE IF NOT EXISTS job_dedup (
+    job_key    TEXT PRIMARY KEY,
+    owner      TEXT NOT NULL,
+    state      TEXT NOT NULL,
+    result     JSONB,
+    expires_at TIMESTAMPTZ NOT NULL
+)`
+
+	claimQuery = `
+INSERT INTO job_dedup (job_key, owner, state, expires_at)
+VALUES ($1, $2, 'running', now() + $3 * interval '1 millisecond')
+ON CONFLICT (job_key) DO UPDATE
+    SET owner = EXCLUDED.owner, state = 'running', result = NULL, expires_at = EXCLUDED.expires_at
+    WHERE job_dedup.expires_at < now()
+RETURNING owner`
+
+	selectQuery = `SELECT owner, state, result FROM job_dedup WHERE job_key = $1`
+
+	completeQuery = `
+UPDATE job_dedup
+SET state = 'completed', result = $3, expires_at = now() + $4 * interval '1 millisecond'
+WHERE job_key = $1 AND owner = $2`
+
+	releaseQuery = `DELETE FROM job_dedup WHERE job_key = $1 AND owner = $2 AND state = 'running'`
+)
+
+// PostgresStore — общее для всех воркеров хранилище в PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+	if dsn == "" {
+		return nil, errors.New("DEDUP_POSTGRES_DSN is required for postgres dedup store")
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, createTableQuery); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create job_dedup table: %w", err)
+	}
+
+	return &PostgresStore{db: db}, nil
+}
+
+func (s *PostgresStore) Claim(ctx context.Context, key, owner string, ttl time.Duration) (Claim, error) {
+	var winner string
+	err := s.db.QueryRowContext(ctx, claimQuery, key, owner, ttl.Milliseconds()).Scan(&winner)
+	if err == nil {
+		return Claim{Outcome: OutcomeAcquired, Owner: winner}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return Claim{}, fmt.Errorf("failed to claim job %s: %w", key, err)
+	}
+
+	// Запись существует и не истекла — смотрим, в каком она состоянии
+	var (
+		state  string
+		result []byte
+		claim  Claim
+	)
+	if err := s.db.QueryRowContext(ctx, selectQuery, key).Scan(&claim.Owner, &state, &result); err != nil {
+		return Claim{}, fmt.Errorf("failed to read job %s state: %w", key, err)
+	}
+
+	if state != "completed" {
+		claim.Outcome = OutcomeRunning
+		return claim, nil
+	}
+	claim.Outcome = OutcomeCompleted
+	if err := json.Unmarshal(result, &claim.Result); err != nil {
+		return Claim{}, fmt.Errorf("failed to decode cached result of job %s: %w", key, err)
+	}
+	return claim, nil
+}
+
+func (s *PostgresStore) Complete(
+	ctx context.Context,
+	key, owner string,
+	result models.JobResult,
+	ttl time.Duration,
+) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, completeQuery, key, owner, data, ttl.Milliseconds()); err != nil {
+		return fmt.Errorf("failed to store result of job %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Release(ctx context.Context, key, owner string) error {
+	if _, err := s.db.ExecContext(ctx, releaseQuery, key, owner); err != nil {
+		return fmt.Errorf("failed to release job %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
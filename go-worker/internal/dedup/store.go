@@ -0,0 +1,69 @@
+package dedup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// Outcome — итог попытки захватить задачу.
+type Outcome int
+
+const (
+	// OutcomeAcquired — задача захвачена этим воркером, ее нужно выполнить.
+	OutcomeAcquired Outcome = iota
+	// OutcomeRunning — задача уже выполняется (здесь или на другом воркере).
+	OutcomeRunning
+	// OutcomeCompleted — задача уже выполнена, в Claim.Result сохраненный результат.
+	OutcomeCompleted
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeAcquired:
+		return "acquired"
+	case OutcomeRunning:
+		return "running"
+	case OutcomeCompleted:
+		return "completed"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(o))
+	}
+}
+
+// Claim — ответ хранилища на попытку захвата.
+type Claim struct {
+	Outcome Outcome
+	Owner   string
+	Result  models.JobResult
+}
+
+// Store — хранилище состояний задач для подавления дублей.
+// Запись "running" живет ttl и истекает, если владелец упал;
+// после Complete запись хранит результат еще ttl.
+type Store interface {
+	// Claim атомарно захватывает ключ, если записи нет или она истекла.
+	Claim(ctx context.Context, key, owner string, ttl time.Duration) (Claim, error)
+	// Complete сохраняет результат задачи, захваченной owner.
+	Complete(ctx context.Context, key, owner string, result models.JobResult, ttl time.Duration) error
+	// Release снимает захват, не сохраняя результат.
+	Release(ctx context.Context, key, owner string) error
+	Close() error
+}
+
+// NewStore создает хранилище по DEDUP_STORE. Для "none" возвращает nil.
+func NewStore(ctx context.Context, cfg *config.Config) (Store, error) {
+	switch cfg.DedupStore {
+	case "none", "":
+		return nil, nil //nolint:nilnil // дедупликация отключена
+	case "memory":
+		return NewMemoryStore(cfg.DedupCacheSize), nil
+	case "postgres":
+		return NewPostgresStore(ctx, cfg.DedupPostgresDSN)
+	default:
+		return nil, fmt.Errorf("unknown DEDUP_STORE %q", cfg.DedupStore)
+	}
+}
@@ -0,0 +1,129 @@
+package delay
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/cronexpr"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// cronEntry — задача по расписанию из секции cron.
+type cronEntry struct {
+	name     string
+	jobType  models.JobType
+	payload  string
+	schedule *cronexpr.Schedule
+	loc      *time.Location
+}
+
+func newCronEntries(jobs []config.CronJobConfig) ([]cronEntry, error) {
+	entries := make([]cronEntry, 0, len(jobs))
+	for _, job := range jobs {
+		jobType, err := jobregistry.ResolveJobType(pb.JobTask_UNKNOWN_TYPE, job.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cron %s: %w", job.Name, err)
+		}
+		if jobType == models.JobTypeWorkflow {
+			// Состояние workflow хранится по ID задачи, а у задач по расписанию его нет
+			return nil, fmt.Errorf("cron %s: WORKFLOW jobs cannot be scheduled", job.Name)
+		}
+
+		schedule, err := cronexpr.Parse(job.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("cron %s: %w", job.Name, err)
+		}
+		loc := time.UTC
+		if job.Timezone != "" {
+			if loc, err = time.LoadLocation(job.Timezone); err != nil {
+				return nil, fmt.Errorf("cron %s: %w", job.Name, err)
+			}
+		}
+
+		payload := []byte("{}")
+		if len(job.Payload) > 0 {
+			if payload, err = json.Marshal(job.Payload); err != nil {
+				return nil, fmt.Errorf("cron %s: invalid payload: %w", job.Name, err)
+			}
+		}
+
+		entries = append(entries, cronEntry{
+			name:     job.Name,
+			jobType:  jobType,
+			payload:  string(payload),
+			schedule: schedule,
+			loc:      loc,
+		})
+	}
+	return entries, nil
+}
+
+// job — запуск задачи по расписанию в момент at.
+func (e cronEntry) job(at time.Time) models.Job {
+	return models.Job{
+		Type:      e.jobType,
+		Payload:   e.payload,
+		CreatedAt: at.Unix(),
+		NotBefore: at,
+		Cron:      e.name,
+	}
+}
+
+// runCron заранее откладывает ближайший запуск каждой задачи, а в момент
+// запуска — следующий. Ключ запуска включает его время, поэтому после
+// перезапуска воркера или при общем хранилище запуск не дублируется.
+func (s *Scheduler) runCron() {
+	defer s.wg.Done()
+
+	next := make([]time.Time, len(s.cron))
+	for i, e := range s.cron {
+		next[i] = s.scheduleNext(e, time.Now())
+	}
+
+	for {
+		var earliest time.Time
+		for _, t := range next {
+			if !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+				earliest = t
+			}
+		}
+		if earliest.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(earliest))
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		for i, e := range s.cron {
+			if !next[i].IsZero() && !next[i].After(time.Now()) {
+				next[i] = s.scheduleNext(e, next[i])
+			}
+		}
+	}
+}
+
+// scheduleNext откладывает первый запуск после after и возвращает его время.
+func (s *Scheduler) scheduleNext(e cronEntry, after time.Time) time.Time {
+	at := e.schedule.Next(after.In(e.loc))
+	if at.IsZero() {
+		slog.Warn("Cron schedule has no upcoming runs", slog.String("cron", e.name))
+		return at
+	}
+	if err := s.delay(s.ctx, e.job(at), "cron"); err != nil {
+		slog.Error("Failed to schedule cron job", slog.String("cron", e.name), slog.String("error", err.Error()))
+	}
+	return at
+}
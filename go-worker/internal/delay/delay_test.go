@@ -0,0 +1,97 @@
+package delay_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/delay"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+func TestFileStoreSurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	now := time.Now()
+
+	store, err := delay.OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := models.Job{ID: 1, Type: models.JobTypeSleep, Attempt: 1, NotBefore: now.Add(-time.Second)}
+	first := models.Job{ID: 2, Type: models.JobTypeSleep, Attempt: 1, NotBefore: now.Add(-time.Minute)}
+	future := models.Job{ID: 3, Type: models.JobTypeSleep, Attempt: 1, NotBefore: now.Add(time.Hour)}
+	for _, job := range []models.Job{later, first, future, first} {
+		if err := store.Add(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = delay.OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	due, err := store.Due(ctx, now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 2 || due[0].ID != first.ID || due[1].ID != later.ID {
+		t.Fatalf("expected jobs 2 and 1 in due order, got %+v", due)
+	}
+
+	// Выданные задачи не выдаются повторно до удаления
+	if again, _ := store.Due(ctx, now, 10); len(again) != 0 {
+		t.Fatalf("due jobs returned twice: %+v", again)
+	}
+	for _, job := range due {
+		if err := store.Remove(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store.Close()
+	store, err = delay.OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if due, _ := store.Due(ctx, now.Add(2*time.Hour), 10); len(due) != 1 || due[0].ID != future.ID {
+		t.Fatalf("expected only job 3 after reopen, got %+v", due)
+	}
+}
+
+func TestSchedulerReleasesDueJobs(t *testing.T) {
+	store, err := delay.OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	jobs := make(chan models.Job, 1)
+	cfg := &config.Config{DelayPollInterval: 10 * time.Millisecond}
+	s, err := delay.NewScheduler(cfg, store, jobs, func() bool { return false }, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Start()
+	defer s.Stop()
+
+	notBefore := time.Now().Add(100 * time.Millisecond)
+	if err := s.Delay(context.Background(), models.Job{ID: 7, Type: models.JobTypeSleep, Attempt: 1, NotBefore: notBefore}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case job := <-jobs:
+		if job.ID != 7 || time.Now().Before(notBefore) {
+			t.Fatalf("job released too early or wrong job: %+v", job)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("delayed job was not released")
+	}
+}
@@ -0,0 +1,195 @@
+package delay
+
+import (
+	"container/heap"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+const (
+	jobFileExt = ".json"
+	tmpFileExt = ".tmp"
+)
+
+// FileStore хранит каждую отложенную задачу в отдельном файле каталога
+// и держит в памяти очередь по времени выполнения. Каталог принадлежит
+// одному воркеру.
+type FileStore struct {
+	mu      sync.Mutex
+	dir     string
+	jobs    map[string]models.Job // Ожидающие и выданные Due задачи
+	claimed map[string]bool       // Выданы Due, но еще не удалены
+	queue   dueQueue
+}
+
+// OpenFileStore открывает каталог и загружает сохраненные задачи.
+// Нечитаемые файлы удаляются с предупреждением в логе.
+func OpenFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create delay dir: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read delay dir: %w", err)
+	}
+
+	s := &FileStore{
+		dir:     dir,
+		jobs:    make(map[string]models.Job),
+		claimed: make(map[string]bool),
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		switch {
+		case e.IsDir():
+			continue
+		case strings.HasSuffix(e.Name(), tmpFileExt):
+			// Недописанный при падении файл
+			_ = os.Remove(path)
+			continue
+		case !strings.HasSuffix(e.Name(), jobFileExt):
+			continue
+		}
+
+		var job models.Job
+		data, err := os.ReadFile(path)
+		if err == nil {
+			err = json.Unmarshal(data, &job)
+		}
+		if err != nil {
+			slog.Warn("Dropping unreadable delayed job", slog.String("file", path), slog.String("error", err.Error()))
+			_ = os.Remove(path)
+			continue
+		}
+		s.push(storeKey(job), job)
+	}
+
+	if len(s.jobs) > 0 {
+		slog.Info("Delayed jobs loaded", slog.String("dir", dir), slog.Int("jobs", len(s.jobs)))
+	}
+	return s, nil
+}
+
+func (s *FileStore) Add(_ context.Context, job models.Job) error {
+	key := storeKey(job)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[key]; exists {
+		return nil
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode delayed job: %w", err)
+	}
+	if err := writeFileSync(s.path(key), data); err != nil {
+		return err
+	}
+	s.push(key, job)
+	return nil
+}
+
+func (s *FileStore) Due(_ context.Context, now time.Time, limit int) ([]models.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []models.Job
+	for len(res) < limit && s.queue.Len() > 0 && !s.queue[0].due.After(now) {
+		item := heap.Pop(&s.queue).(dueItem)
+		job, ok := s.jobs[item.key]
+		if !ok || s.claimed[item.key] {
+			continue
+		}
+		s.claimed[item.key] = true
+		res = append(res, job)
+	}
+	return res, nil
+}
+
+func (s *FileStore) Remove(_ context.Context, job models.Job) error {
+	key := storeKey(job)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.jobs, key)
+	delete(s.claimed, key)
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove delayed job: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) Close() error {
+	return nil
+}
+
+func (s *FileStore) push(key string, job models.Job) {
+	s.jobs[key] = job
+	heap.Push(&s.queue, dueItem{key: key, due: job.NotBefore})
+}
+
+// path — файл задачи; ключ кодируется, так как может содержать '/'.
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, base64.RawURLEncoding.EncodeToString([]byte(key))+jobFileExt)
+}
+
+// writeFileSync записывает файл целиком через временный файл,
+// чтобы после падения не остался частично записанный.
+func writeFileSync(path string, data []byte) error {
+	tmp := path + tmpFileExt
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to write delayed job: %w", err)
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write delayed job: %w", err)
+	}
+	return nil
+}
+
+type dueItem struct {
+	key string
+	due time.Time
+}
+
+// dueQueue — min-heap по времени выполнения.
+type dueQueue []dueItem
+
+func (q dueQueue) Len() int           { return len(q) }
+func (q dueQueue) Less(i, j int) bool { return q[i].due.Before(q[j].due) }
+func (q dueQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *dueQueue) Push(x any)        { *q = append(*q, x.(dueItem)) }
+
+func (q *dueQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,31 @@
+package delay
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	jobsDelayed = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "delay",
+		Name:      "jobs_delayed_total",
+		Help:      "Jobs put into the delay store, by source (kafka, cron).",
+	}, []string{"source"})
+
+	jobsReleased = promauto.With(metrics.Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "delay",
+		Name:      "jobs_released_total",
+		Help:      "Delayed jobs handed to the worker pool when due.",
+	})
+
+	releaseLag = promauto.With(metrics.Registry).NewHistogram(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "delay",
+		Name:      "release_lag_seconds",
+		Help:      "Time between not_before of a delayed job and its release to the worker pool.",
+		Buckets:   []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 300},
+	})
+)
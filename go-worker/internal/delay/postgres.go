@@ -0,0 +1,134 @@
+package delay
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	_ "github.com/jackc/pgx/v5/stdlib" // драйвер "pgx" для database/sql
+)
+
+// claimLease — время, на которое Due закрепляет задачу за воркером. Если воркер
+// упал, не передав задачу в пул, ее заберет другой воркер после истечения аренды.
+const claimLease = time.Minute
+
+const (
+	createTableQuery = `
+CREATE TABLE IF NOT EXISTS delayed_jobs (
+    job_key       TEXT PRIMARY KEY,
+    job           JSONB NOT NULL,
+    due_at        TIMESTAMPTZ NOT NULL,
+    claimed_until TIMESTAMPTZ
+)`
+
+	createIndexQuery = `CREATE INDEX IF NOT EXISTS delayed_jobs_due_at ON delayed_jobs (due_at)`
+
+	insertQuery = `
+INSERT INTO delayed_jobs (job_key, job, due_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (job_key) DO NOTHING`
+
+	// SKIP LOCKED: воркеры забирают разные задачи, не дожидаясь друг друга
+	claimQuery = `
+UPDATE delayed_jobs SET claimed_until = now() + $2 * interval '1 millisecond'
+WHERE job_key IN (
+    SELECT job_key FROM delayed_jobs
+    WHERE due_at <= $1 AND (claimed_until IS NULL OR claimed_until < now())
+    ORDER BY due_at
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING job_key, job`
+
+	deleteQuery = `DELETE FROM delayed_jobs WHERE job_key = $1`
+)
+
+// PostgresStore — общее для всех воркеров хранилище в PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+	if dsn == "" {
+		return nil, errors.New("DELAY_POSTGRES_DSN is required for postgres delay store")
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres: %w", err)
+	}
+	for _, q := range []string{createTableQuery, createIndexQuery} {
+		if _, err := db.ExecContext(ctx, q); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to create delayed_jobs table: %w", err)
+		}
+	}
+
+	return &PostgresStore{db: db}, nil
+}
+
+func (s *PostgresStore) Add(ctx context.Context, job models.Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode delayed job: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, insertQuery, storeKey(job), data, job.NotBefore); err != nil {
+		return fmt.Errorf("failed to save delayed job %s: %w", job.Key(), err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Due(ctx context.Context, now time.Time, limit int) ([]models.Job, error) {
+	rows, err := s.db.QueryContext(ctx, claimQuery, now, claimLease.Milliseconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim delayed jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		res       []models.Job
+		undecoded []string
+	)
+	for rows.Next() {
+		var (
+			key  string
+			data []byte
+		)
+		if err := rows.Scan(&key, &data); err != nil {
+			return nil, fmt.Errorf("failed to read delayed job: %w", err)
+		}
+		var job models.Job
+		if err := json.Unmarshal(data, &job); err != nil {
+			slog.Warn("Dropping undecodable delayed job", slog.String("key", key), slog.String("error", err.Error()))
+			undecoded = append(undecoded, key)
+			continue
+		}
+		res = append(res, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read delayed jobs: %w", err)
+	}
+
+	for _, key := range undecoded {
+		if _, err := s.db.ExecContext(ctx, deleteQuery, key); err != nil {
+			return nil, fmt.Errorf("failed to remove delayed job %s: %w", key, err)
+		}
+	}
+	return res, nil
+}
+
+func (s *PostgresStore) Remove(ctx context.Context, job models.Job) error {
+	if _, err := s.db.ExecContext(ctx, deleteQuery, storeKey(job)); err != nil {
+		return fmt.Errorf("failed to remove delayed job %s: %w", job.Key(), err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,145 @@
+package delay
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// releaseBatch — сколько наступивших задач забирается из хранилища за раз.
+const releaseBatch = 100
+
+// Scheduler откладывает задачи с NotBefore в будущем и передает их в канал
+// задач пула, когда время наступило. Пока чтение из Kafka на паузе
+// (pause, drain), отложенные задачи тоже не запускаются.
+type Scheduler struct {
+	store        Store
+	jobsChan     chan<- models.Job
+	paused       func() bool
+	pollInterval time.Duration
+	cron         []cronEntry
+
+	wg   sync.WaitGroup
+	stop chan struct{}
+	ctx  context.Context
+}
+
+func NewScheduler(
+	cfg *config.Config,
+	store Store,
+	jobsChan chan<- models.Job,
+	paused func() bool,
+	ctx context.Context,
+) (*Scheduler, error) {
+	cron, err := newCronEntries(cfg.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scheduler{
+		store:        store,
+		jobsChan:     jobsChan,
+		paused:       paused,
+		pollInterval: cfg.DelayPollInterval,
+		cron:         cron,
+		stop:         make(chan struct{}),
+		ctx:          ctx,
+	}, nil
+}
+
+// Start запускает передачу наступивших задач в пул и расписание cron.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.run()
+
+	if len(s.cron) > 0 {
+		s.wg.Add(1)
+		go s.runCron()
+	}
+}
+
+// Stop останавливает передачу задач в пул. Вызывается до закрытия канала задач;
+// отложенные задачи остаются в хранилище до следующего запуска.
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// Delay сохраняет задачу до наступления NotBefore.
+func (s *Scheduler) Delay(ctx context.Context, job models.Job) error {
+	return s.delay(ctx, job, "kafka")
+}
+
+func (s *Scheduler) delay(ctx context.Context, job models.Job, source string) error {
+	if err := s.store.Add(ctx, job); err != nil {
+		return fmt.Errorf("failed to delay job: %w", err)
+	}
+	jobsDelayed.WithLabelValues(source).Inc()
+	slog.Info("Job delayed",
+		slog.String("job_key", job.Key()),
+		slog.String("job_type", string(job.Type)),
+		slog.Int("attempt", job.Attempt),
+		slog.Time("not_before", job.NotBefore),
+	)
+	return nil
+}
+
+func (s *Scheduler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.paused() {
+				s.release()
+			}
+		}
+	}
+}
+
+// release передает в пул задачи, время которых наступило. Задача удаляется
+// из хранилища после передачи: после падения она может выполниться повторно.
+func (s *Scheduler) release() {
+	for {
+		jobs, err := s.store.Due(s.ctx, time.Now(), releaseBatch)
+		if err != nil {
+			slog.Error("Failed to load due delayed jobs", slog.String("error", err.Error()))
+			return
+		}
+
+		for _, job := range jobs {
+			// Ожидание в очереди пула считается от момента передачи
+			job.ReceivedAt = time.Now()
+			select {
+			case s.jobsChan <- job:
+			case <-s.stop:
+				return
+			}
+
+			jobsReleased.Inc()
+			releaseLag.Observe(time.Since(job.NotBefore).Seconds())
+			if err := s.store.Remove(s.ctx, job); err != nil {
+				slog.Error("Failed to remove released delayed job",
+					slog.String("job_key", job.Key()),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+
+		if len(jobs) < releaseBatch {
+			return
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Package delay откладывает задачи, время выполнения которых еще не наступило
+// (JobTask.not_before), и запускает задачи по расписанию из секции cron.
+// Такие задачи ждут в хранилище, а не в пуле, и возвращаются в канал задач,
+// когда время наступило.
+package delay
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// Store — хранилище отложенных задач, переживающее перезапуск воркера.
+type Store interface {
+	// Add сохраняет задачу до наступления NotBefore. Задача с тем же ключом
+	// и попыткой уже сохранена — ничего не делает.
+	Add(ctx context.Context, job models.Job) error
+	// Due забирает до limit задач, время которых наступило к now. Забранная задача
+	// не возвращается повторно, пока не удалена (file) или до истечения аренды (postgres).
+	Due(ctx context.Context, now time.Time, limit int) ([]models.Job, error)
+	// Remove удаляет задачу, переданную в пул.
+	Remove(ctx context.Context, job models.Job) error
+	Close() error
+}
+
+// NewStore создает хранилище по DELAY_STORE.
+func NewStore(ctx context.Context, cfg *config.Config) (Store, error) {
+	switch cfg.DelayStore {
+	case "file", "":
+		return OpenFileStore(cfg.DelayDir)
+	case "postgres":
+		return NewPostgresStore(ctx, cfg.DelayPostgresDSN)
+	default:
+		return nil, fmt.Errorf("unknown DELAY_STORE %q", cfg.DelayStore)
+	}
+}
+
+// storeKey — ключ отложенной задачи: повтор задачи с новой попыткой
+// откладывается отдельно от предыдущей. Ключ обычной задачи уже содержит попытку.
+func storeKey(job models.Job) string {
+	if job.Workflow == nil && job.Cron == "" {
+		return job.Key()
+	}
+	return job.Key() + "#" + strconv.Itoa(job.Attempt)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 )
 
-type TaskExecutor interface {
-	Execute(ctx context.Context, payload string) (string, error)
+// TaskExecutor — executor с типизированным payload, который уже
+// разобран и провалидирован реестром. Результат — структура,
+// которую воркер кодирует в JSON.
+type TaskExecutor[T any] interface {
+	Execute(ctx context.Context, payload *T) (any, error)
 }
@@ -4,58 +4,100 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/breaker"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
 	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/joblog"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/ratelimit"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 func init() {
-	jobregistry.Register(
+	jobregistry.RegisterTyped(
 		models.JobTypeHttpGet,
 		pb.JobTask_HTTP_GET,
-		func(cfg *config.Config) jobregistry.Executor {
-			return NewHttpGetExecutor().Execute
+		func(cfg *config.Config) jobregistry.TypedExecutor[models.PayloadHttpGet] {
+			opts := cfg.JobType(string(models.JobTypeHttpGet)).Options
+			return NewHttpGetExecutor(breaker.NewSet("http", breaker.SettingsFromConfig(cfg)), opts["user_agent"]).Execute
 		},
 	)
 }
 
 type httpGetExecutor struct {
-	client *http.Client
+	client    *http.Client
+	breakers  *breaker.Set
+	userAgent string // Опция user_agent из job_types.HTTP_GET, пусто — по умолчанию Go
 }
 
-func NewHttpGetExecutor() *httpGetExecutor {
+func NewHttpGetExecutor(breakers *breaker.Set, userAgent string) *httpGetExecutor {
 	return &httpGetExecutor{
 		client: &http.Client{
 			Timeout: 10 * time.Second,
+			// Передает контекст трассировки задачи во внешний сервис
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
 		},
+		breakers:  breakers,
+		userAgent: userAgent,
 	}
 }
 
-func (e *httpGetExecutor) Execute(ctx context.Context, payload string) (string, error) {
-	p, err := models.ParsePayload[models.PayloadHttpGet](payload)
+func (e *httpGetExecutor) Execute(ctx context.Context, p *models.PayloadHttpGet) (any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse payload: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if e.userAgent != "" {
+		req.Header.Set("User-Agent", e.userAgent)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	// Лимит, breaker, логи и метрики хоста используют один ключ: имя без порта
+	host := strings.ToLower(req.URL.Hostname())
+	if err := ratelimit.WaitHost(ctx, host); err != nil {
+		return nil, err
+	}
+
+	logger := joblog.FromContext(ctx).With(slog.String("host", host))
+
+	// Пока хост недоступен, задачи к нему падают сразу с временной ошибкой
+	done, err := e.breakers.Get(host).Allow()
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		logger.Warn("Circuit breaker open, request skipped")
+		return nil, models.Retryable(err)
 	}
 
+	logger.Debug("Sending HTTP request", slog.String("url", p.URL))
 	resp, err := e.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("http request failed: %w", err)
+		// Отмена или дедлайн задачи не говорят о недоступности хоста
+		if ctx.Err() != nil {
+			done(breaker.Ignored)
+		} else {
+			done(breaker.Failure)
+		}
+		return nil, fmt.Errorf("http request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	done(breaker.OutcomeOf(resp.StatusCode < http.StatusInternalServerError))
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read body: %w", err)
+		return nil, fmt.Errorf("failed to read body: %w", err)
 	}
+	logger.Debug("HTTP response received",
+		slog.Int("status_code", resp.StatusCode),
+		slog.Int("body_length", len(body)),
+	)
 
-	return fmt.Sprintf("Status: %d, BodyLen: %d", resp.StatusCode, len(body)), nil
+	return &models.HttpGetResult{
+		StatusCode:  resp.StatusCode,
+		BodyLength:  len(body),
+		CompletedAt: time.Now().Unix(),
+	}, nil
 }
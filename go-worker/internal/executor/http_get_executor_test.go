@@ -0,0 +1,44 @@
+package executor_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/breaker"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/executor"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+func TestHttpGetBreakerKeyedByHostname(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	breakers := breaker.NewSet("http-test", breaker.Settings{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+		HalfOpenMaxCalls: 1,
+	})
+	exec := executor.NewHttpGetExecutor(breakers, "")
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := exec.Execute(context.Background(), &models.PayloadHttpGet{URL: srv.URL}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	// Breaker хоста — по имени без порта, как и лимит RATE_LIMIT_HOSTS
+	if state := breakers.Get(u.Hostname()).State(); state != breaker.StateOpen {
+		t.Errorf("breaker of %s is %s, want open", u.Hostname(), state)
+	}
+	if _, err := exec.Execute(context.Background(), &models.PayloadHttpGet{URL: srv.URL + "/other"}); !models.IsRetryable(err) {
+		t.Errorf("expected retryable error while breaker is open, got %v", err)
+	}
+}
@@ -2,19 +2,22 @@ package executor
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
 	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/joblog"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/progress"
 )
 
 func init() {
-	jobregistry.Register(
+	jobregistry.RegisterTyped(
 		models.JobTypeImageResize,
 		pb.JobTask_IMAGE_RESIZE,
-		func(cfg *config.Config) jobregistry.Executor {
+		func(cfg *config.Config) jobregistry.TypedExecutor[models.PayloadImageResize] {
 			return NewImageResizeExecutor().Execute
 		},
 	)
@@ -26,13 +29,22 @@ func NewImageResizeExecutor() *imageResizeExecutor {
 	return &imageResizeExecutor{}
 }
 
-func (e *imageResizeExecutor) Execute(ctx context.Context, payload string) (string, error) {
-	p, err := models.ParsePayload[models.PayloadImageResize](payload)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse payload: %w", err)
-	}
-
+func (e *imageResizeExecutor) Execute(ctx context.Context, p *models.PayloadImageResize) (any, error) {
 	// TODO: Реализовать реальное изменение размера изображения
-
-	return fmt.Sprintf("Image resized to %dx%d (simulated)", p.Width, p.Height), nil
+	joblog.FromContext(ctx).Debug("Resizing image (simulated)",
+		slog.String("image_url", p.ImageURL),
+		slog.Int("width", p.Width),
+		slog.Int("height", p.Height),
+	)
+	progress.Report(ctx, progress.Update{Percent: 0, Stage: "download"})
+	progress.Report(ctx, progress.Update{Percent: 33, Stage: "resize"})
+	progress.Report(ctx, progress.Update{Percent: 66, Stage: "upload"})
+	progress.Report(ctx, progress.Update{Percent: 100, Stage: "upload"})
+
+	return &models.ImageResizeResult{
+		Width:       p.Width,
+		Height:      p.Height,
+		Simulated:   true,
+		CompletedAt: time.Now().Unix(),
+	}, nil
 }
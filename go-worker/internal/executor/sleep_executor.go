@@ -2,50 +2,64 @@ package executor
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
 	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/joblog"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/progress"
 )
 
 func init() {
-	jobregistry.Register(
+	jobregistry.RegisterTyped(
 		models.JobTypeSleep,
 		pb.JobTask_SLEEP,
-		func(cfg *config.Config) jobregistry.Executor {
-			exec := NewSleepExecutor()
-			return func(ctx context.Context, payload string) (string, error) {
-				return exec.Execute(ctx, payload)
-			}
+		func(cfg *config.Config) jobregistry.TypedExecutor[models.PayloadSleep] {
+			return NewSleepExecutor().Execute
 		},
 	)
 }
 
+const (
+	sleepProgressSteps   = 20
+	minSleepProgressTick = 100 * time.Millisecond
+)
+
 type sleepExecutor struct {
 }
 
-func NewSleepExecutor() TaskExecutor {
+func NewSleepExecutor() TaskExecutor[models.PayloadSleep] {
 	return &sleepExecutor{}
 }
 
-func (se *sleepExecutor) Execute(ctx context.Context, payload string) (string, error) {
-	p, err := models.ParsePayload[models.PayloadSleep](payload)
-	if err != nil {
-		return "", fmt.Errorf("ошибка парсинга: %w", err)
-	}
-
+func (se *sleepExecutor) Execute(ctx context.Context, p *models.PayloadSleep) (any, error) {
 	duration := time.Duration(p.DurationMs) * time.Millisecond
+	start := time.Now()
+	joblog.FromContext(ctx).Debug("Sleeping", slog.Duration("duration", duration))
 
 	timer := time.NewTimer(duration)
 	defer timer.Stop()
 
-	select {
-	case <-ctx.Done():
-		return "", ctx.Err()
-	case <-timer.C:
-		return fmt.Sprintf("Поспали %d ms", p.DurationMs), nil
+	ticker := time.NewTicker(max(duration/sleepProgressSteps, minSleepProgressTick))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			progress.Report(ctx, progress.Update{Percent: 100, Stage: "sleep"})
+			return &models.SleepResult{SleptMs: p.DurationMs}, nil
+		case <-ticker.C:
+			elapsed := time.Since(start)
+			progress.Report(ctx, progress.Update{
+				Percent: float64(elapsed) / float64(duration) * 100,
+				Stage:   "sleep",
+				ETA:     duration - elapsed,
+			})
+		}
 	}
 }
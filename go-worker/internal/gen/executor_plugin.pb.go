@@ -0,0 +1,563 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: executor_plugin.proto
+
+package jobplatform
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PluginDescribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginDescribeRequest) Reset() {
+	*x = PluginDescribeRequest{}
+	mi := &file_executor_plugin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginDescribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginDescribeRequest) ProtoMessage() {}
+
+func (x *PluginDescribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginDescribeRequest.ProtoReflect.Descriptor instead.
+func (*PluginDescribeRequest) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{0}
+}
+
+type PluginDescribeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	JobTypes      []string               `protobuf:"bytes,3,rep,name=job_types,json=jobTypes,proto3" json:"job_types,omitempty"` // Имена типов задач, например "PDF_RENDER"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginDescribeResponse) Reset() {
+	*x = PluginDescribeResponse{}
+	mi := &file_executor_plugin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginDescribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginDescribeResponse) ProtoMessage() {}
+
+func (x *PluginDescribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginDescribeResponse.ProtoReflect.Descriptor instead.
+func (*PluginDescribeResponse) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PluginDescribeResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginDescribeResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PluginDescribeResponse) GetJobTypes() []string {
+	if x != nil {
+		return x.JobTypes
+	}
+	return nil
+}
+
+type PluginExecuteRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JobType        string                 `protobuf:"bytes,1,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	Payload        string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`                                        // JSON параметры задачи
+	DeadlineUnixMs int64                  `protobuf:"varint,3,opt,name=deadline_unix_ms,json=deadlineUnixMs,proto3" json:"deadline_unix_ms,omitempty"` // Дедлайн задачи, 0 — без дедлайна
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PluginExecuteRequest) Reset() {
+	*x = PluginExecuteRequest{}
+	mi := &file_executor_plugin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginExecuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginExecuteRequest) ProtoMessage() {}
+
+func (x *PluginExecuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginExecuteRequest.ProtoReflect.Descriptor instead.
+func (*PluginExecuteRequest) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PluginExecuteRequest) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *PluginExecuteRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *PluginExecuteRequest) GetDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.DeadlineUnixMs
+	}
+	return 0
+}
+
+type PluginProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percent       float64                `protobuf:"fixed64,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	Stage         string                 `protobuf:"bytes,2,opt,name=stage,proto3" json:"stage,omitempty"`
+	EtaSeconds    int64                  `protobuf:"varint,3,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginProgress) Reset() {
+	*x = PluginProgress{}
+	mi := &file_executor_plugin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginProgress) ProtoMessage() {}
+
+func (x *PluginProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginProgress.ProtoReflect.Descriptor instead.
+func (*PluginProgress) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PluginProgress) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *PluginProgress) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *PluginProgress) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+type PluginResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Output        string                 `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"` // JSON результата
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`   // Непустая строка — задача завершилась ошибкой
+	Retryable     bool                   `protobuf:"varint,3,opt,name=retryable,proto3" json:"retryable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginResult) Reset() {
+	*x = PluginResult{}
+	mi := &file_executor_plugin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginResult) ProtoMessage() {}
+
+func (x *PluginResult) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginResult.ProtoReflect.Descriptor instead.
+func (*PluginResult) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PluginResult) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *PluginResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PluginResult) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+type PluginExecuteEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*PluginExecuteEvent_Progress
+	//	*PluginExecuteEvent_Result
+	Event         isPluginExecuteEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginExecuteEvent) Reset() {
+	*x = PluginExecuteEvent{}
+	mi := &file_executor_plugin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginExecuteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginExecuteEvent) ProtoMessage() {}
+
+func (x *PluginExecuteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginExecuteEvent.ProtoReflect.Descriptor instead.
+func (*PluginExecuteEvent) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PluginExecuteEvent) GetEvent() isPluginExecuteEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *PluginExecuteEvent) GetProgress() *PluginProgress {
+	if x != nil {
+		if x, ok := x.Event.(*PluginExecuteEvent_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *PluginExecuteEvent) GetResult() *PluginResult {
+	if x != nil {
+		if x, ok := x.Event.(*PluginExecuteEvent_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isPluginExecuteEvent_Event interface {
+	isPluginExecuteEvent_Event()
+}
+
+type PluginExecuteEvent_Progress struct {
+	Progress *PluginProgress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type PluginExecuteEvent_Result struct {
+	Result *PluginResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*PluginExecuteEvent_Progress) isPluginExecuteEvent_Event() {}
+
+func (*PluginExecuteEvent_Result) isPluginExecuteEvent_Event() {}
+
+type PluginHealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginHealthRequest) Reset() {
+	*x = PluginHealthRequest{}
+	mi := &file_executor_plugin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginHealthRequest) ProtoMessage() {}
+
+func (x *PluginHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginHealthRequest.ProtoReflect.Descriptor instead.
+func (*PluginHealthRequest) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{6}
+}
+
+type PluginHealthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serving       bool                   `protobuf:"varint,1,opt,name=serving,proto3" json:"serving,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginHealthResponse) Reset() {
+	*x = PluginHealthResponse{}
+	mi := &file_executor_plugin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginHealthResponse) ProtoMessage() {}
+
+func (x *PluginHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginHealthResponse.ProtoReflect.Descriptor instead.
+func (*PluginHealthResponse) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PluginHealthResponse) GetServing() bool {
+	if x != nil {
+		return x.Serving
+	}
+	return false
+}
+
+var File_executor_plugin_proto protoreflect.FileDescriptor
+
+const file_executor_plugin_proto_rawDesc = "" +
+	"\n" +
+	"\x15executor_plugin.proto\x12\vjobplatform\"\x17\n" +
+	"\x15PluginDescribeRequest\"c\n" +
+	"\x16PluginDescribeResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1b\n" +
+	"\tjob_types\x18\x03 \x03(\tR\bjobTypes\"u\n" +
+	"\x14PluginExecuteRequest\x12\x19\n" +
+	"\bjob_type\x18\x01 \x01(\tR\ajobType\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload\x12(\n" +
+	"\x10deadline_unix_ms\x18\x03 \x01(\x03R\x0edeadlineUnixMs\"a\n" +
+	"\x0ePluginProgress\x12\x18\n" +
+	"\apercent\x18\x01 \x01(\x01R\apercent\x12\x14\n" +
+	"\x05stage\x18\x02 \x01(\tR\x05stage\x12\x1f\n" +
+	"\veta_seconds\x18\x03 \x01(\x03R\n" +
+	"etaSeconds\"Z\n" +
+	"\fPluginResult\x12\x16\n" +
+	"\x06output\x18\x01 \x01(\tR\x06output\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1c\n" +
+	"\tretryable\x18\x03 \x01(\bR\tretryable\"\x8d\x01\n" +
+	"\x12PluginExecuteEvent\x129\n" +
+	"\bprogress\x18\x01 \x01(\v2\x1b.jobplatform.PluginProgressH\x00R\bprogress\x123\n" +
+	"\x06result\x18\x02 \x01(\v2\x19.jobplatform.PluginResultH\x00R\x06resultB\a\n" +
+	"\x05event\"\x15\n" +
+	"\x13PluginHealthRequest\"0\n" +
+	"\x14PluginHealthResponse\x12\x18\n" +
+	"\aserving\x18\x01 \x01(\bR\aserving2\x85\x02\n" +
+	"\x0eExecutorPlugin\x12S\n" +
+	"\bDescribe\x12\".jobplatform.PluginDescribeRequest\x1a#.jobplatform.PluginDescribeResponse\x12O\n" +
+	"\aExecute\x12!.jobplatform.PluginExecuteRequest\x1a\x1f.jobplatform.PluginExecuteEvent0\x01\x12M\n" +
+	"\x06Health\x12 .jobplatform.PluginHealthRequest\x1a!.jobplatform.PluginHealthResponseB|\n" +
+	"\x14com.jobplatform.grpcP\x01Zbgithub.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen;jobplatformb\x06proto3"
+
+var (
+	file_executor_plugin_proto_rawDescOnce sync.Once
+	file_executor_plugin_proto_rawDescData []byte
+)
+
+func file_executor_plugin_proto_rawDescGZIP() []byte {
+	file_executor_plugin_proto_rawDescOnce.Do(func() {
+		file_executor_plugin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_executor_plugin_proto_rawDesc), len(file_executor_plugin_proto_rawDesc)))
+	})
+	return file_executor_plugin_proto_rawDescData
+}
+
+var file_executor_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_executor_plugin_proto_goTypes = []any{
+	(*PluginDescribeRequest)(nil),  // 0: jobplatform.PluginDescribeRequest
+	(*PluginDescribeResponse)(nil), // 1: jobplatform.PluginDescribeResponse
+	(*PluginExecuteRequest)(nil),   // 2: jobplatform.PluginExecuteRequest
+	(*PluginProgress)(nil),         // 3: jobplatform.PluginProgress
+	(*PluginResult)(nil),           // 4: jobplatform.PluginResult
+	(*PluginExecuteEvent)(nil),     // 5: jobplatform.PluginExecuteEvent
+	(*PluginHealthRequest)(nil),    // 6: jobplatform.PluginHealthRequest
+	(*PluginHealthResponse)(nil),   // 7: jobplatform.PluginHealthResponse
+}
+var file_executor_plugin_proto_depIdxs = []int32{
+	3, // 0: jobplatform.PluginExecuteEvent.progress:type_name -> jobplatform.PluginProgress
+	4, // 1: jobplatform.PluginExecuteEvent.result:type_name -> jobplatform.PluginResult
+	0, // 2: jobplatform.ExecutorPlugin.Describe:input_type -> jobplatform.PluginDescribeRequest
+	2, // 3: jobplatform.ExecutorPlugin.Execute:input_type -> jobplatform.PluginExecuteRequest
+	6, // 4: jobplatform.ExecutorPlugin.Health:input_type -> jobplatform.PluginHealthRequest
+	1, // 5: jobplatform.ExecutorPlugin.Describe:output_type -> jobplatform.PluginDescribeResponse
+	5, // 6: jobplatform.ExecutorPlugin.Execute:output_type -> jobplatform.PluginExecuteEvent
+	7, // 7: jobplatform.ExecutorPlugin.Health:output_type -> jobplatform.PluginHealthResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_executor_plugin_proto_init() }
+func file_executor_plugin_proto_init() {
+	if File_executor_plugin_proto != nil {
+		return
+	}
+	file_executor_plugin_proto_msgTypes[5].OneofWrappers = []any{
+		(*PluginExecuteEvent_Progress)(nil),
+		(*PluginExecuteEvent_Result)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_executor_plugin_proto_rawDesc), len(file_executor_plugin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_executor_plugin_proto_goTypes,
+		DependencyIndexes: file_executor_plugin_proto_depIdxs,
+		MessageInfos:      file_executor_plugin_proto_msgTypes,
+	}.Build()
+	File_executor_plugin_proto = out.File
+	file_executor_plugin_proto_goTypes = nil
+	file_executor_plugin_proto_depIdxs = nil
+}
@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: executor_plugin.proto
+
+package jobplatform
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExecutorPlugin_Describe_FullMethodName = "/jobplatform.ExecutorPlugin/Describe"
+	ExecutorPlugin_Execute_FullMethodName  = "/jobplatform.ExecutorPlugin/Execute"
+	ExecutorPlugin_Health_FullMethodName   = "/jobplatform.ExecutorPlugin/Health"
+)
+
+// ExecutorPluginClient is the client API for ExecutorPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Протокол внешних executor'ов (Go-воркер -> процесс плагина).
+// Воркер запускает плагин с переменной окружения JOB_PLUGIN_ADDR
+// (например, unix:///tmp/job-plugins/echo.sock) и ждет, пока тот начнет слушать этот адрес.
+type ExecutorPluginClient interface {
+	// Какие типы задач обслуживает плагин
+	Describe(ctx context.Context, in *PluginDescribeRequest, opts ...grpc.CallOption) (*PluginDescribeResponse, error)
+	// Выполнение задачи: поток событий прогресса, завершающийся результатом
+	Execute(ctx context.Context, in *PluginExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PluginExecuteEvent], error)
+	// Проверка, что плагин жив и готов принимать задачи
+	Health(ctx context.Context, in *PluginHealthRequest, opts ...grpc.CallOption) (*PluginHealthResponse, error)
+}
+
+type executorPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutorPluginClient(cc grpc.ClientConnInterface) ExecutorPluginClient {
+	return &executorPluginClient{cc}
+}
+
+func (c *executorPluginClient) Describe(ctx context.Context, in *PluginDescribeRequest, opts ...grpc.CallOption) (*PluginDescribeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PluginDescribeResponse)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_Describe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) Execute(ctx context.Context, in *PluginExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PluginExecuteEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExecutorPlugin_ServiceDesc.Streams[0], ExecutorPlugin_Execute_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PluginExecuteRequest, PluginExecuteEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExecutorPlugin_ExecuteClient = grpc.ServerStreamingClient[PluginExecuteEvent]
+
+func (c *executorPluginClient) Health(ctx context.Context, in *PluginHealthRequest, opts ...grpc.CallOption) (*PluginHealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PluginHealthResponse)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_Health_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExecutorPluginServer is the server API for ExecutorPlugin service.
+// All implementations must embed UnimplementedExecutorPluginServer
+// for forward compatibility.
+//
+// Протокол внешних executor'ов (Go-воркер -> процесс плагина).
+// Воркер запускает плагин с переменной окружения JOB_PLUGIN_ADDR
+// (например, unix:///tmp/job-plugins/echo.sock) и ждет, пока тот начнет слушать этот адрес.
+type ExecutorPluginServer interface {
+	// Какие типы задач обслуживает плагин
+	Describe(context.Context, *PluginDescribeRequest) (*PluginDescribeResponse, error)
+	// Выполнение задачи: поток событий прогресса, завершающийся результатом
+	Execute(*PluginExecuteRequest, grpc.ServerStreamingServer[PluginExecuteEvent]) error
+	// Проверка, что плагин жив и готов принимать задачи
+	Health(context.Context, *PluginHealthRequest) (*PluginHealthResponse, error)
+	mustEmbedUnimplementedExecutorPluginServer()
+}
+
+// UnimplementedExecutorPluginServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExecutorPluginServer struct{}
+
+func (UnimplementedExecutorPluginServer) Describe(context.Context, *PluginDescribeRequest) (*PluginDescribeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Describe not implemented")
+}
+func (UnimplementedExecutorPluginServer) Execute(*PluginExecuteRequest, grpc.ServerStreamingServer[PluginExecuteEvent]) error {
+	return status.Error(codes.Unimplemented, "method Execute not implemented")
+}
+func (UnimplementedExecutorPluginServer) Health(context.Context, *PluginHealthRequest) (*PluginHealthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedExecutorPluginServer) mustEmbedUnimplementedExecutorPluginServer() {}
+func (UnimplementedExecutorPluginServer) testEmbeddedByValue()                        {}
+
+// UnsafeExecutorPluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExecutorPluginServer will
+// result in compilation errors.
+type UnsafeExecutorPluginServer interface {
+	mustEmbedUnimplementedExecutorPluginServer()
+}
+
+func RegisterExecutorPluginServer(s grpc.ServiceRegistrar, srv ExecutorPluginServer) {
+	// If the following call panics, it indicates UnimplementedExecutorPluginServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExecutorPlugin_ServiceDesc, srv)
+}
+
+func _ExecutorPlugin_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PluginDescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_Describe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).Describe(ctx, req.(*PluginDescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_Execute_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PluginExecuteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecutorPluginServer).Execute(m, &grpc.GenericServerStream[PluginExecuteRequest, PluginExecuteEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExecutorPlugin_ExecuteServer = grpc.ServerStreamingServer[PluginExecuteEvent]
+
+func _ExecutorPlugin_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PluginHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_Health_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).Health(ctx, req.(*PluginHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExecutorPlugin_ServiceDesc is the grpc.ServiceDesc for ExecutorPlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExecutorPlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jobplatform.ExecutorPlugin",
+	HandlerType: (*ExecutorPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler:    _ExecutorPlugin_Describe_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _ExecutorPlugin_Health_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Execute",
+			Handler:       _ExecutorPlugin_Execute_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "executor_plugin.proto",
+}
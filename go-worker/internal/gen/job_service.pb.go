@@ -73,6 +73,55 @@ func (JobTask_TaskType) EnumDescriptor() ([]byte, []int) {
 	return file_job_service_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type JobResult_Status int32
+
+const (
+	JobResult_UNKNOWN_STATUS JobResult_Status = 0
+	JobResult_COMPLETED      JobResult_Status = 1
+	JobResult_FAILED         JobResult_Status = 2
+)
+
+// Enum value maps for JobResult_Status.
+var (
+	JobResult_Status_name = map[int32]string{
+		0: "UNKNOWN_STATUS",
+		1: "COMPLETED",
+		2: "FAILED",
+	}
+	JobResult_Status_value = map[string]int32{
+		"UNKNOWN_STATUS": 0,
+		"COMPLETED":      1,
+		"FAILED":         2,
+	}
+)
+
+func (x JobResult_Status) Enum() *JobResult_Status {
+	p := new(JobResult_Status)
+	*p = x
+	return p
+}
+
+func (x JobResult_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobResult_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_job_service_proto_enumTypes[1].Descriptor()
+}
+
+func (JobResult_Status) Type() protoreflect.EnumType {
+	return &file_job_service_proto_enumTypes[1]
+}
+
+func (x JobResult_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobResult_Status.Descriptor instead.
+func (JobResult_Status) EnumDescriptor() ([]byte, []int) {
+	return file_job_service_proto_rawDescGZIP(), []int{2, 0}
+}
+
 type UpdateJobStatusRequest_JobStatus int32
 
 const (
@@ -106,11 +155,11 @@ func (x UpdateJobStatusRequest_JobStatus) String() string {
 }
 
 func (UpdateJobStatusRequest_JobStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_job_service_proto_enumTypes[1].Descriptor()
+	return file_job_service_proto_enumTypes[2].Descriptor()
 }
 
 func (UpdateJobStatusRequest_JobStatus) Type() protoreflect.EnumType {
-	return &file_job_service_proto_enumTypes[1]
+	return &file_job_service_proto_enumTypes[2]
 }
 
 func (x UpdateJobStatusRequest_JobStatus) Number() protoreflect.EnumNumber {
@@ -119,16 +168,26 @@ func (x UpdateJobStatusRequest_JobStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use UpdateJobStatusRequest_JobStatus.Descriptor instead.
 func (UpdateJobStatusRequest_JobStatus) EnumDescriptor() ([]byte, []int) {
-	return file_job_service_proto_rawDescGZIP(), []int{1, 0}
+	return file_job_service_proto_rawDescGZIP(), []int{3, 0}
 }
 
 // Схема данных для Kafka (Java -> Kafka -> Go)
 type JobTask struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         int64                  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Type          JobTask_TaskType       `protobuf:"varint,2,opt,name=type,proto3,enum=jobplatform.JobTask_TaskType" json:"type,omitempty"`
-	Payload       string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`                       // JSON параметры
-	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	JobId     int64                  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Type      JobTask_TaskType       `protobuf:"varint,2,opt,name=type,proto3,enum=jobplatform.JobTask_TaskType" json:"type,omitempty"`
+	Payload   string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`                       // JSON параметры
+	CreatedAt int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	// Имя типа задачи, например "PDF_RENDER". Если задано, имеет приоритет над type:
+	// новые типы не требуют изменения enum и одновременного релиза Java и Go.
+	TypeName string `protobuf:"bytes,5,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	Attempt  int32  `protobuf:"varint,6,opt,name=attempt,proto3" json:"attempt,omitempty"` // Номер попытки выполнения, 0 или 1 — первая
+	// Задано, если задача — шаг workflow: ее публикует воркер, выполнивший
+	// предыдущие шаги, а job_id — идентификатор задачи WORKFLOW
+	WorkflowStep *WorkflowStep `protobuf:"bytes,7,opt,name=workflow_step,json=workflowStep,proto3" json:"workflow_step,omitempty"`
+	// Unix timestamp, раньше которого задачу нельзя выполнять, 0 — сразу.
+	// Воркер откладывает такую задачу, не занимая пул, до наступления времени
+	NotBefore     int64 `protobuf:"varint,8,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -191,19 +250,214 @@ func (x *JobTask) GetCreatedAt() int64 {
 	return 0
 }
 
+func (x *JobTask) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *JobTask) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *JobTask) GetWorkflowStep() *WorkflowStep {
+	if x != nil {
+		return x.WorkflowStep
+	}
+	return nil
+}
+
+func (x *JobTask) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+// Шаг workflow внутри задачи WORKFLOW
+type WorkflowStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Step          string                 `protobuf:"bytes,1,opt,name=step,proto3" json:"step,omitempty"` // Имя шага из определения workflow
+	Run           int32                  `protobuf:"varint,2,opt,name=run,proto3" json:"run,omitempty"`  // Попытка задачи WORKFLOW, в которой запущен шаг
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowStep) Reset() {
+	*x = WorkflowStep{}
+	mi := &file_job_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowStep) ProtoMessage() {}
+
+func (x *WorkflowStep) ProtoReflect() protoreflect.Message {
+	mi := &file_job_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowStep.ProtoReflect.Descriptor instead.
+func (*WorkflowStep) Descriptor() ([]byte, []int) {
+	return file_job_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WorkflowStep) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *WorkflowStep) GetRun() int32 {
+	if x != nil {
+		return x.Run
+	}
+	return 0
+}
+
+// Результат задачи для потребителей вне управляющего сервиса (аналитика, биллинг).
+// Схема данных для Kafka (Go -> Kafka, топик KAFKA_RESULTS_TOPIC), ключ сообщения — job_id
+type JobResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         int64                  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobType       string                 `protobuf:"bytes,2,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"` // Имя типа задачи из jobregistry
+	Status        JobResult_Status       `protobuf:"varint,3,opt,name=status,proto3,enum=jobplatform.JobResult_Status" json:"status,omitempty"`
+	Result        string                 `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"` // JSON результат executor'а
+	ErrorMessage  string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Retryable     bool                   `protobuf:"varint,6,opt,name=retryable,proto3" json:"retryable,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Attempt       int32                  `protobuf:"varint,8,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,9,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"` // Воркер, выполнивший задачу
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobResult) Reset() {
+	*x = JobResult{}
+	mi := &file_job_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobResult) ProtoMessage() {}
+
+func (x *JobResult) ProtoReflect() protoreflect.Message {
+	mi := &file_job_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobResult.ProtoReflect.Descriptor instead.
+func (*JobResult) Descriptor() ([]byte, []int) {
+	return file_job_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JobResult) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobResult) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *JobResult) GetStatus() JobResult_Status {
+	if x != nil {
+		return x.Status
+	}
+	return JobResult_UNKNOWN_STATUS
+}
+
+func (x *JobResult) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *JobResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *JobResult) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+func (x *JobResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *JobResult) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *JobResult) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
 type UpdateJobStatusRequest struct {
 	state         protoimpl.MessageState           `protogen:"open.v1"`
 	JobId         int64                            `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	Status        UpdateJobStatusRequest_JobStatus `protobuf:"varint,2,opt,name=status,proto3,enum=jobplatform.UpdateJobStatusRequest_JobStatus" json:"status,omitempty"`
-	Result        string                           `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"` // JSON результат или строка
+	Result        string                           `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"` // JSON результат executor'а (объект, закодированный один раз)
 	ErrorMessage  string                           `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Retryable     bool                             `protobuf:"varint,5,opt,name=retryable,proto3" json:"retryable,omitempty"`                     // Ошибка временная (например, разомкнут circuit breaker), задачу можно повторить
+	DurationMs    int64                            `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // Время выполнения задачи
+	Attempt       int32                            `protobuf:"varint,7,opt,name=attempt,proto3" json:"attempt,omitempty"`                         // Номер попытки выполнения
+	Logs          string                           `protobuf:"bytes,8,opt,name=logs,proto3" json:"logs,omitempty"`                                // Лог выполнения задачи (ограниченный по размеру), если воркер его собирает
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateJobStatusRequest) Reset() {
 	*x = UpdateJobStatusRequest{}
-	mi := &file_job_service_proto_msgTypes[1]
+	mi := &file_job_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -215,7 +469,7 @@ func (x *UpdateJobStatusRequest) String() string {
 func (*UpdateJobStatusRequest) ProtoMessage() {}
 
 func (x *UpdateJobStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_job_service_proto_msgTypes[1]
+	mi := &file_job_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -228,7 +482,7 @@ func (x *UpdateJobStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateJobStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateJobStatusRequest) Descriptor() ([]byte, []int) {
-	return file_job_service_proto_rawDescGZIP(), []int{1}
+	return file_job_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UpdateJobStatusRequest) GetJobId() int64 {
@@ -259,6 +513,34 @@ func (x *UpdateJobStatusRequest) GetErrorMessage() string {
 	return ""
 }
 
+func (x *UpdateJobStatusRequest) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+func (x *UpdateJobStatusRequest) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *UpdateJobStatusRequest) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *UpdateJobStatusRequest) GetLogs() string {
+	if x != nil {
+		return x.Logs
+	}
+	return ""
+}
+
 type UpdateJobStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -268,7 +550,7 @@ type UpdateJobStatusResponse struct {
 
 func (x *UpdateJobStatusResponse) Reset() {
 	*x = UpdateJobStatusResponse{}
-	mi := &file_job_service_proto_msgTypes[2]
+	mi := &file_job_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -280,7 +562,7 @@ func (x *UpdateJobStatusResponse) String() string {
 func (*UpdateJobStatusResponse) ProtoMessage() {}
 
 func (x *UpdateJobStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_job_service_proto_msgTypes[2]
+	mi := &file_job_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,7 +575,7 @@ func (x *UpdateJobStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateJobStatusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateJobStatusResponse) Descriptor() ([]byte, []int) {
-	return file_job_service_proto_rawDescGZIP(), []int{2}
+	return file_job_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UpdateJobStatusResponse) GetSuccess() bool {
@@ -303,36 +585,484 @@ func (x *UpdateJobStatusResponse) GetSuccess() bool {
 	return false
 }
 
+type JobProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         int64                  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Percent       float64                `protobuf:"fixed64,2,opt,name=percent,proto3" json:"percent,omitempty"`                        // 0..100
+	Stage         string                 `protobuf:"bytes,3,opt,name=stage,proto3" json:"stage,omitempty"`                              // Название текущего этапа, например "download"
+	EtaSeconds    int64                  `protobuf:"varint,4,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"` // Оценка оставшегося времени, 0 — неизвестно
+	ReportedAt    int64                  `protobuf:"varint,5,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"` // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobProgressRequest) Reset() {
+	*x = JobProgressRequest{}
+	mi := &file_job_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobProgressRequest) ProtoMessage() {}
+
+func (x *JobProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobProgressRequest.ProtoReflect.Descriptor instead.
+func (*JobProgressRequest) Descriptor() ([]byte, []int) {
+	return file_job_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *JobProgressRequest) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobProgressRequest) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *JobProgressRequest) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *JobProgressRequest) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *JobProgressRequest) GetReportedAt() int64 {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return 0
+}
+
+type JobProgressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobProgressResponse) Reset() {
+	*x = JobProgressResponse{}
+	mi := &file_job_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobProgressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobProgressResponse) ProtoMessage() {}
+
+func (x *JobProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_job_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobProgressResponse.ProtoReflect.Descriptor instead.
+func (*JobProgressResponse) Descriptor() ([]byte, []int) {
+	return file_job_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *JobProgressResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type JobHeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         int64                  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,2,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`     // Идентификатор экземпляра воркера
+	ElapsedMs     int64                  `protobuf:"varint,3,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"` // Сколько задача уже выполняется
+	Attempt       int32                  `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`                      // Попытка, которую выполняет воркер: аренда прошлой попытки отзывается
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobHeartbeatRequest) Reset() {
+	*x = JobHeartbeatRequest{}
+	mi := &file_job_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobHeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobHeartbeatRequest) ProtoMessage() {}
+
+func (x *JobHeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobHeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*JobHeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_job_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JobHeartbeatRequest) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobHeartbeatRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *JobHeartbeatRequest) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *JobHeartbeatRequest) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+type JobHeartbeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeaseRevoked  bool                   `protobuf:"varint,1,opt,name=lease_revoked,json=leaseRevoked,proto3" json:"lease_revoked,omitempty"` // Аренда отозвана (например, задача переназначена) — выполнение нужно прервать
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobHeartbeatResponse) Reset() {
+	*x = JobHeartbeatResponse{}
+	mi := &file_job_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobHeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobHeartbeatResponse) ProtoMessage() {}
+
+func (x *JobHeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_job_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobHeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*JobHeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_job_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *JobHeartbeatResponse) GetLeaseRevoked() bool {
+	if x != nil {
+		return x.LeaseRevoked
+	}
+	return false
+}
+
+func (x *JobHeartbeatResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type WorkerRegistrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	JobTypes      []string               `protobuf:"bytes,3,rep,name=job_types,json=jobTypes,proto3" json:"job_types,omitempty"` // Имена типов задач из jobregistry
+	PoolSize      int32                  `protobuf:"varint,4,opt,name=pool_size,json=poolSize,proto3" json:"pool_size,omitempty"`
+	InFlight      int32                  `protobuf:"varint,5,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`       // Сколько задач выполняется сейчас
+	Queued        int32                  `protobuf:"varint,6,opt,name=queued,proto3" json:"queued,omitempty"`                           // Сколько задач ждет в буфере воркера
+	StartedAt     int64                  `protobuf:"varint,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`    // Unix timestamp старта воркера
+	ReportedAt    int64                  `protobuf:"varint,8,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"` // Unix timestamp отчета
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerRegistrationRequest) Reset() {
+	*x = WorkerRegistrationRequest{}
+	mi := &file_job_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerRegistrationRequest) ProtoMessage() {}
+
+func (x *WorkerRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*WorkerRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_job_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WorkerRegistrationRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *WorkerRegistrationRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *WorkerRegistrationRequest) GetJobTypes() []string {
+	if x != nil {
+		return x.JobTypes
+	}
+	return nil
+}
+
+func (x *WorkerRegistrationRequest) GetPoolSize() int32 {
+	if x != nil {
+		return x.PoolSize
+	}
+	return 0
+}
+
+func (x *WorkerRegistrationRequest) GetInFlight() int32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *WorkerRegistrationRequest) GetQueued() int32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *WorkerRegistrationRequest) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *WorkerRegistrationRequest) GetReportedAt() int64 {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return 0
+}
+
+type WorkerRegistrationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerRegistrationResponse) Reset() {
+	*x = WorkerRegistrationResponse{}
+	mi := &file_job_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerRegistrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerRegistrationResponse) ProtoMessage() {}
+
+func (x *WorkerRegistrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_job_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerRegistrationResponse.ProtoReflect.Descriptor instead.
+func (*WorkerRegistrationResponse) Descriptor() ([]byte, []int) {
+	return file_job_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WorkerRegistrationResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
 var File_job_service_proto protoreflect.FileDescriptor
 
 const file_job_service_proto_rawDesc = "" +
 	"\n" +
-	"\x11job_service.proto\x12\vjobplatform\"\xd5\x01\n" +
+	"\x11job_service.proto\x12\vjobplatform\"\xeb\x02\n" +
 	"\aJobTask\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\x03R\x05jobId\x121\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x1d.jobplatform.JobTask.TaskTypeR\x04type\x12\x18\n" +
 	"\apayload\x18\x03 \x01(\tR\apayload\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"G\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1b\n" +
+	"\ttype_name\x18\x05 \x01(\tR\btypeName\x12\x18\n" +
+	"\aattempt\x18\x06 \x01(\x05R\aattempt\x12>\n" +
+	"\rworkflow_step\x18\a \x01(\v2\x19.jobplatform.WorkflowStepR\fworkflowStep\x12\x1d\n" +
+	"\n" +
+	"not_before\x18\b \x01(\x03R\tnotBefore\"G\n" +
 	"\bTaskType\x12\x10\n" +
 	"\fUNKNOWN_TYPE\x10\x00\x12\f\n" +
 	"\bHTTP_GET\x10\x01\x12\x10\n" +
 	"\fIMAGE_RESIZE\x10\x02\x12\t\n" +
-	"\x05SLEEP\x10\x03\"\xef\x01\n" +
+	"\x05SLEEP\x10\x03\"4\n" +
+	"\fWorkflowStep\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\tR\x04step\x12\x10\n" +
+	"\x03run\x18\x02 \x01(\x05R\x03run\"\xe0\x02\n" +
+	"\tJobResult\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x03R\x05jobId\x12\x19\n" +
+	"\bjob_type\x18\x02 \x01(\tR\ajobType\x125\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1d.jobplatform.JobResult.StatusR\x06status\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\x12#\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\x12\x1c\n" +
+	"\tretryable\x18\x06 \x01(\bR\tretryable\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x03R\n" +
+	"durationMs\x12\x18\n" +
+	"\aattempt\x18\b \x01(\x05R\aattempt\x12\x1b\n" +
+	"\tworker_id\x18\t \x01(\tR\bworkerId\"7\n" +
+	"\x06Status\x12\x12\n" +
+	"\x0eUNKNOWN_STATUS\x10\x00\x12\r\n" +
+	"\tCOMPLETED\x10\x01\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x02\"\xdc\x02\n" +
 	"\x16UpdateJobStatusRequest\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\x03R\x05jobId\x12E\n" +
 	"\x06status\x18\x02 \x01(\x0e2-.jobplatform.UpdateJobStatusRequest.JobStatusR\x06status\x12\x16\n" +
 	"\x06result\x18\x03 \x01(\tR\x06result\x12#\n" +
-	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\":\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12\x1c\n" +
+	"\tretryable\x18\x05 \x01(\bR\tretryable\x12\x1f\n" +
+	"\vduration_ms\x18\x06 \x01(\x03R\n" +
+	"durationMs\x12\x18\n" +
+	"\aattempt\x18\a \x01(\x05R\aattempt\x12\x12\n" +
+	"\x04logs\x18\b \x01(\tR\x04logs\":\n" +
 	"\tJobStatus\x12\x12\n" +
 	"\x0eUNKNOWN_STATUS\x10\x00\x12\r\n" +
 	"\tCOMPLETED\x10\x01\x12\n" +
 	"\n" +
 	"\x06FAILED\x10\x02\"3\n" +
 	"\x17UpdateJobStatusResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2p\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x9d\x01\n" +
+	"\x12JobProgressRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x03R\x05jobId\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x01R\apercent\x12\x14\n" +
+	"\x05stage\x18\x03 \x01(\tR\x05stage\x12\x1f\n" +
+	"\veta_seconds\x18\x04 \x01(\x03R\n" +
+	"etaSeconds\x12\x1f\n" +
+	"\vreported_at\x18\x05 \x01(\x03R\n" +
+	"reportedAt\"/\n" +
+	"\x13JobProgressResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x82\x01\n" +
+	"\x13JobHeartbeatRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x03R\x05jobId\x12\x1b\n" +
+	"\tworker_id\x18\x02 \x01(\tR\bworkerId\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x03 \x01(\x03R\telapsedMs\x12\x18\n" +
+	"\aattempt\x18\x04 \x01(\x05R\aattempt\"S\n" +
+	"\x14JobHeartbeatResponse\x12#\n" +
+	"\rlease_revoked\x18\x01 \x01(\bR\fleaseRevoked\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x81\x02\n" +
+	"\x19WorkerRegistrationRequest\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1b\n" +
+	"\tjob_types\x18\x03 \x03(\tR\bjobTypes\x12\x1b\n" +
+	"\tpool_size\x18\x04 \x01(\x05R\bpoolSize\x12\x1b\n" +
+	"\tin_flight\x18\x05 \x01(\x05R\binFlight\x12\x16\n" +
+	"\x06queued\x18\x06 \x01(\x05R\x06queued\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\a \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vreported_at\x18\b \x01(\x03R\n" +
+	"reportedAt\"8\n" +
+	"\x1aWorkerRegistrationResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted2\xfd\x02\n" +
 	"\x10JobStatusService\x12\\\n" +
-	"\x0fUpdateJobStatus\x12#.jobplatform.UpdateJobStatusRequest\x1a$.jobplatform.UpdateJobStatusResponseB|\n" +
+	"\x0fUpdateJobStatus\x12#.jobplatform.UpdateJobStatusRequest\x1a$.jobplatform.UpdateJobStatusResponse\x12V\n" +
+	"\x11ReportJobProgress\x12\x1f.jobplatform.JobProgressRequest\x1a .jobplatform.JobProgressResponse\x12P\n" +
+	"\tHeartbeat\x12 .jobplatform.JobHeartbeatRequest\x1a!.jobplatform.JobHeartbeatResponse\x12a\n" +
+	"\x0eRegisterWorker\x12&.jobplatform.WorkerRegistrationRequest\x1a'.jobplatform.WorkerRegistrationResponseB|\n" +
 	"\x14com.jobplatform.grpcP\x01Zbgithub.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen;jobplatformb\x06proto3"
 
 var (
@@ -347,25 +1077,42 @@ func file_job_service_proto_rawDescGZIP() []byte {
 	return file_job_service_proto_rawDescData
 }
 
-var file_job_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_job_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_job_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_job_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_job_service_proto_goTypes = []any{
 	(JobTask_TaskType)(0),                 // 0: jobplatform.JobTask.TaskType
-	(UpdateJobStatusRequest_JobStatus)(0), // 1: jobplatform.UpdateJobStatusRequest.JobStatus
-	(*JobTask)(nil),                       // 2: jobplatform.JobTask
-	(*UpdateJobStatusRequest)(nil),        // 3: jobplatform.UpdateJobStatusRequest
-	(*UpdateJobStatusResponse)(nil),       // 4: jobplatform.UpdateJobStatusResponse
+	(JobResult_Status)(0),                 // 1: jobplatform.JobResult.Status
+	(UpdateJobStatusRequest_JobStatus)(0), // 2: jobplatform.UpdateJobStatusRequest.JobStatus
+	(*JobTask)(nil),                       // 3: jobplatform.JobTask
+	(*WorkflowStep)(nil),                  // 4: jobplatform.WorkflowStep
+	(*JobResult)(nil),                     // 5: jobplatform.JobResult
+	(*UpdateJobStatusRequest)(nil),        // 6: jobplatform.UpdateJobStatusRequest
+	(*UpdateJobStatusResponse)(nil),       // 7: jobplatform.UpdateJobStatusResponse
+	(*JobProgressRequest)(nil),            // 8: jobplatform.JobProgressRequest
+	(*JobProgressResponse)(nil),           // 9: jobplatform.JobProgressResponse
+	(*JobHeartbeatRequest)(nil),           // 10: jobplatform.JobHeartbeatRequest
+	(*JobHeartbeatResponse)(nil),          // 11: jobplatform.JobHeartbeatResponse
+	(*WorkerRegistrationRequest)(nil),     // 12: jobplatform.WorkerRegistrationRequest
+	(*WorkerRegistrationResponse)(nil),    // 13: jobplatform.WorkerRegistrationResponse
 }
 var file_job_service_proto_depIdxs = []int32{
-	0, // 0: jobplatform.JobTask.type:type_name -> jobplatform.JobTask.TaskType
-	1, // 1: jobplatform.UpdateJobStatusRequest.status:type_name -> jobplatform.UpdateJobStatusRequest.JobStatus
-	3, // 2: jobplatform.JobStatusService.UpdateJobStatus:input_type -> jobplatform.UpdateJobStatusRequest
-	4, // 3: jobplatform.JobStatusService.UpdateJobStatus:output_type -> jobplatform.UpdateJobStatusResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: jobplatform.JobTask.type:type_name -> jobplatform.JobTask.TaskType
+	4,  // 1: jobplatform.JobTask.workflow_step:type_name -> jobplatform.WorkflowStep
+	1,  // 2: jobplatform.JobResult.status:type_name -> jobplatform.JobResult.Status
+	2,  // 3: jobplatform.UpdateJobStatusRequest.status:type_name -> jobplatform.UpdateJobStatusRequest.JobStatus
+	6,  // 4: jobplatform.JobStatusService.UpdateJobStatus:input_type -> jobplatform.UpdateJobStatusRequest
+	8,  // 5: jobplatform.JobStatusService.ReportJobProgress:input_type -> jobplatform.JobProgressRequest
+	10, // 6: jobplatform.JobStatusService.Heartbeat:input_type -> jobplatform.JobHeartbeatRequest
+	12, // 7: jobplatform.JobStatusService.RegisterWorker:input_type -> jobplatform.WorkerRegistrationRequest
+	7,  // 8: jobplatform.JobStatusService.UpdateJobStatus:output_type -> jobplatform.UpdateJobStatusResponse
+	9,  // 9: jobplatform.JobStatusService.ReportJobProgress:output_type -> jobplatform.JobProgressResponse
+	11, // 10: jobplatform.JobStatusService.Heartbeat:output_type -> jobplatform.JobHeartbeatResponse
+	13, // 11: jobplatform.JobStatusService.RegisterWorker:output_type -> jobplatform.WorkerRegistrationResponse
+	8,  // [8:12] is the sub-list for method output_type
+	4,  // [4:8] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_job_service_proto_init() }
@@ -378,8 +1125,8 @@ func file_job_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_job_service_proto_rawDesc), len(file_job_service_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   3,
+			NumEnums:      3,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	JobStatusService_UpdateJobStatus_FullMethodName = "/jobplatform.JobStatusService/UpdateJobStatus"
+	JobStatusService_UpdateJobStatus_FullMethodName   = "/jobplatform.JobStatusService/UpdateJobStatus"
+	JobStatusService_ReportJobProgress_FullMethodName = "/jobplatform.JobStatusService/ReportJobProgress"
+	JobStatusService_Heartbeat_FullMethodName         = "/jobplatform.JobStatusService/Heartbeat"
+	JobStatusService_RegisterWorker_FullMethodName    = "/jobplatform.JobStatusService/RegisterWorker"
 )
 
 // JobStatusServiceClient is the client API for JobStatusService service.
@@ -30,6 +33,13 @@ const (
 type JobStatusServiceClient interface {
 	// Воркер вызывает этот метод, чтобы сообщить результат обработки
 	UpdateJobStatus(ctx context.Context, in *UpdateJobStatusRequest, opts ...grpc.CallOption) (*UpdateJobStatusResponse, error)
+	// Воркер периодически сообщает прогресс долгих задач (для прогресс-баров в UI)
+	ReportJobProgress(ctx context.Context, in *JobProgressRequest, opts ...grpc.CallOption) (*JobProgressResponse, error)
+	// Воркер периодически подтверждает аренду каждой выполняемой задачи
+	Heartbeat(ctx context.Context, in *JobHeartbeatRequest, opts ...grpc.CallOption) (*JobHeartbeatResponse, error)
+	// Воркер регистрируется при старте и периодически сообщает свои возможности и нагрузку,
+	// чтобы сервис мог маршрутизировать задачи и отклонять типы, которые никто не обслуживает
+	RegisterWorker(ctx context.Context, in *WorkerRegistrationRequest, opts ...grpc.CallOption) (*WorkerRegistrationResponse, error)
 }
 
 type jobStatusServiceClient struct {
@@ -50,6 +60,36 @@ func (c *jobStatusServiceClient) UpdateJobStatus(ctx context.Context, in *Update
 	return out, nil
 }
 
+func (c *jobStatusServiceClient) ReportJobProgress(ctx context.Context, in *JobProgressRequest, opts ...grpc.CallOption) (*JobProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobProgressResponse)
+	err := c.cc.Invoke(ctx, JobStatusService_ReportJobProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobStatusServiceClient) Heartbeat(ctx context.Context, in *JobHeartbeatRequest, opts ...grpc.CallOption) (*JobHeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobHeartbeatResponse)
+	err := c.cc.Invoke(ctx, JobStatusService_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobStatusServiceClient) RegisterWorker(ctx context.Context, in *WorkerRegistrationRequest, opts ...grpc.CallOption) (*WorkerRegistrationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerRegistrationResponse)
+	err := c.cc.Invoke(ctx, JobStatusService_RegisterWorker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // JobStatusServiceServer is the server API for JobStatusService service.
 // All implementations must embed UnimplementedJobStatusServiceServer
 // for forward compatibility.
@@ -58,6 +98,13 @@ func (c *jobStatusServiceClient) UpdateJobStatus(ctx context.Context, in *Update
 type JobStatusServiceServer interface {
 	// Воркер вызывает этот метод, чтобы сообщить результат обработки
 	UpdateJobStatus(context.Context, *UpdateJobStatusRequest) (*UpdateJobStatusResponse, error)
+	// Воркер периодически сообщает прогресс долгих задач (для прогресс-баров в UI)
+	ReportJobProgress(context.Context, *JobProgressRequest) (*JobProgressResponse, error)
+	// Воркер периодически подтверждает аренду каждой выполняемой задачи
+	Heartbeat(context.Context, *JobHeartbeatRequest) (*JobHeartbeatResponse, error)
+	// Воркер регистрируется при старте и периодически сообщает свои возможности и нагрузку,
+	// чтобы сервис мог маршрутизировать задачи и отклонять типы, которые никто не обслуживает
+	RegisterWorker(context.Context, *WorkerRegistrationRequest) (*WorkerRegistrationResponse, error)
 	mustEmbedUnimplementedJobStatusServiceServer()
 }
 
@@ -71,6 +118,15 @@ type UnimplementedJobStatusServiceServer struct{}
 func (UnimplementedJobStatusServiceServer) UpdateJobStatus(context.Context, *UpdateJobStatusRequest) (*UpdateJobStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateJobStatus not implemented")
 }
+func (UnimplementedJobStatusServiceServer) ReportJobProgress(context.Context, *JobProgressRequest) (*JobProgressResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportJobProgress not implemented")
+}
+func (UnimplementedJobStatusServiceServer) Heartbeat(context.Context, *JobHeartbeatRequest) (*JobHeartbeatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedJobStatusServiceServer) RegisterWorker(context.Context, *WorkerRegistrationRequest) (*WorkerRegistrationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterWorker not implemented")
+}
 func (UnimplementedJobStatusServiceServer) mustEmbedUnimplementedJobStatusServiceServer() {}
 func (UnimplementedJobStatusServiceServer) testEmbeddedByValue()                          {}
 
@@ -110,6 +166,60 @@ func _JobStatusService_UpdateJobStatus_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobStatusService_ReportJobProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobStatusServiceServer).ReportJobProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobStatusService_ReportJobProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobStatusServiceServer).ReportJobProgress(ctx, req.(*JobProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobStatusService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobHeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobStatusServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobStatusService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobStatusServiceServer).Heartbeat(ctx, req.(*JobHeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobStatusService_RegisterWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobStatusServiceServer).RegisterWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobStatusService_RegisterWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobStatusServiceServer).RegisterWorker(ctx, req.(*WorkerRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // JobStatusService_ServiceDesc is the grpc.ServiceDesc for JobStatusService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,6 +231,18 @@ var JobStatusService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateJobStatus",
 			Handler:    _JobStatusService_UpdateJobStatus_Handler,
 		},
+		{
+			MethodName: "ReportJobProgress",
+			Handler:    _JobStatusService_ReportJobProgress_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _JobStatusService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "RegisterWorker",
+			Handler:    _JobStatusService_RegisterWorker_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "job_service.proto",
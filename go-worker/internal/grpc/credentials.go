@@ -0,0 +1,236 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// WorkerIDHeader — метаданные запроса с идентификатором воркера.
+const WorkerIDHeader = "x-worker-id"
+
+// dialOptions собирает транспортные и per-RPC credentials из конфигурации.
+// Контекст трассировки передается в метаданных каждого вызова.
+func dialOptions(cfg *config.Config) ([]grpc.DialOption, error) {
+	opts := []grpc.DialOption{grpc.WithStatsHandler(otelgrpc.NewClientHandler())}
+
+	if !cfg.GrpcTLSEnabled {
+		return append(opts, grpc.WithTransportCredentials(insecure.NewCredentials())), nil
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("grpc tls: %w", err)
+	}
+	opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+
+	if cfg.GrpcAuthToken != "" || cfg.GrpcAuthTokenFile != "" {
+		token := NewTokenCredentials(cfg.GrpcAuthToken, cfg.GrpcAuthTokenFile, cfg.WorkerID)
+		opts = append(opts, grpc.WithPerRPCCredentials(token))
+	}
+
+	return opts, nil
+}
+
+// newTLSConfig создает tls.Config, который берет CA и клиентский сертификат
+// из certFiles при каждом handshake'е: после ротации файлов новые соединения
+// используют новые сертификаты без перезапуска воркера.
+func newTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	files := &certFiles{
+		caFile:   cfg.GrpcTLSCAFile,
+		certFile: cfg.GrpcTLSCertFile,
+		keyFile:  cfg.GrpcTLSKeyFile,
+	}
+	// Ошибки в файлах видны сразу при старте, а не при первом соединении
+	if err := files.reload(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.GrpcTLSServerName,
+	}
+	if files.certFile != "" {
+		tlsConfig.GetClientCertificate = files.clientCertificate
+	}
+	if files.caFile != "" {
+		// Стандартная проверка использует неизменяемый RootCAs, поэтому
+		// цепочка проверяется вручную по актуальному пулу CA
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // проверка выполняется в VerifyConnection
+		tlsConfig.VerifyConnection = files.verifyConnection
+	}
+
+	return tlsConfig, nil
+}
+
+// certFiles кэширует CA и клиентский сертификат, перечитывая файлы при изменении mtime.
+type certFiles struct {
+	caFile, certFile, keyFile string
+
+	mu      sync.Mutex
+	caMod   time.Time
+	certMod time.Time
+	keyMod  time.Time
+	roots   *x509.CertPool
+	cert    *tls.Certificate
+}
+
+func (f *certFiles) reload() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.caFile != "" {
+		mod, changed, err := modified(f.caFile, f.caMod)
+		if err != nil {
+			return err
+		}
+		if changed {
+			pem, err := os.ReadFile(f.caFile)
+			if err != nil {
+				return fmt.Errorf("failed to read CA file: %w", err)
+			}
+			roots := x509.NewCertPool()
+			if !roots.AppendCertsFromPEM(pem) {
+				return fmt.Errorf("no certificates found in %s", f.caFile)
+			}
+			f.roots, f.caMod = roots, mod
+		}
+	}
+
+	if f.certFile != "" {
+		certMod, certChanged, err := modified(f.certFile, f.certMod)
+		if err != nil {
+			return err
+		}
+		keyMod, keyChanged, err := modified(f.keyFile, f.keyMod)
+		if err != nil {
+			return err
+		}
+		if certChanged || keyChanged {
+			cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+			if err != nil {
+				return fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			f.cert, f.certMod, f.keyMod = &cert, certMod, keyMod
+		}
+	}
+
+	return nil
+}
+
+func (f *certFiles) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	// Если новые файлы еще не записаны целиком, продолжаем с прежним сертификатом
+	if err := f.reload(); err != nil && f.current().cert == nil {
+		return nil, err
+	}
+	return f.current().cert, nil
+}
+
+func (f *certFiles) verifyConnection(cs tls.ConnectionState) error {
+	if err := f.reload(); err != nil && f.current().roots == nil {
+		return err
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("server presented no certificates")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         f.current().roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+type certSnapshot struct {
+	roots *x509.CertPool
+	cert  *tls.Certificate
+}
+
+func (f *certFiles) current() certSnapshot {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return certSnapshot{roots: f.roots, cert: f.cert}
+}
+
+// TokenCredentials добавляет к каждому RPC bearer-токен и идентификатор воркера.
+// Токен из файла перечитывается при изменении файла (например, projected token в Kubernetes).
+type TokenCredentials struct {
+	workerID string
+	file     string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+}
+
+func NewTokenCredentials(token, file, workerID string) *TokenCredentials {
+	return &TokenCredentials{token: token, file: file, workerID: workerID}
+}
+
+func (c *TokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token, err := c.currentToken()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"authorization": "Bearer " + token,
+		WorkerIDHeader:  c.workerID,
+	}, nil
+}
+
+// RequireTransportSecurity запрещает отправку токена по незашифрованному соединению.
+func (c *TokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+func (c *TokenCredentials) currentToken() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == "" {
+		return c.token, nil
+	}
+
+	mod, changed, err := modified(c.file, c.modTime)
+	if err != nil {
+		if c.token != "" {
+			return c.token, nil
+		}
+		return "", err
+	}
+	if changed {
+		data, err := os.ReadFile(c.file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		c.token, c.modTime = strings.TrimSpace(string(data)), mod
+	}
+	return c.token, nil
+}
+
+// modified сообщает, изменился ли файл после since.
+func modified(path string, since time.Time) (time.Time, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return info.ModTime(), !info.ModTime().Equal(since), nil
+}
@@ -0,0 +1,46 @@
+package grpc_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/grpc"
+)
+
+func TestTokenCredentialsReloadsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	writeToken(t, path, "first\n", time.Now().Add(-time.Minute))
+
+	creds := grpc.NewTokenCredentials("", path, "worker-1")
+
+	md, err := creds.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("metadata: %v", err)
+	}
+	if md["authorization"] != "Bearer first" || md[grpc.WorkerIDHeader] != "worker-1" {
+		t.Fatalf("unexpected metadata %v", md)
+	}
+
+	writeToken(t, path, "second", time.Now())
+
+	md, err = creds.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("metadata: %v", err)
+	}
+	if md["authorization"] != "Bearer second" {
+		t.Errorf("expected rotated token, got %q", md["authorization"])
+	}
+}
+
+func writeToken(t *testing.T, path, token string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(token), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -5,23 +5,29 @@ import (
 	"fmt"
 	"time"
 
-	"google.golang.org/grpc/credentials/insecure"
-
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/breaker"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
 
 	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type GrpcClient struct {
 	Timeout time.Duration
 	conn    *grpc.ClientConn
 	client  pb.JobStatusServiceClient
+	breaker *breaker.Breaker
 }
 
 func NewGrpcClient(cfg *config.Config) (*GrpcClient, error) {
-	conn, err := grpc.NewClient(cfg.GrpcServerAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	opts, err := dialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(cfg.GrpcServerAddress, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -30,6 +36,7 @@ func NewGrpcClient(cfg *config.Config) (*GrpcClient, error) {
 		conn:    conn,
 		client:  pb.NewJobStatusServiceClient(conn),
 		Timeout: cfg.GrpcTimeout,
+		breaker: breaker.New("grpc:"+cfg.GrpcServerAddress, breaker.SettingsFromConfig(cfg)),
 	}, nil
 }
 
@@ -37,11 +44,23 @@ func (gc *GrpcClient) Close() error {
 	return gc.conn.Close()
 }
 
+// Healthy сообщает, можно ли сейчас отправлять статусы: breaker не разомкнут.
+func (gc *GrpcClient) Healthy() bool {
+	return gc.breaker.Ready()
+}
+
 func (gc *GrpcClient) SendStatus(ctx context.Context, req *pb.UpdateJobStatusRequest) error {
-	ctx, cancel := context.WithTimeout(ctx, gc.Timeout)
+	// Пока сервер недоступен, не ждем полный таймаут на каждый результат
+	done, err := gc.breaker.Allow()
+	if err != nil {
+		return err
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, gc.Timeout)
 	defer cancel()
 
-	resp, err := gc.client.UpdateJobStatus(ctx, req)
+	resp, err := gc.client.UpdateJobStatus(rpcCtx, req)
+	done(rpcOutcome(ctx, err))
 
 	if err != nil {
 		return fmt.Errorf("не удалось выполнить вызов gRPC: %w", err)
@@ -52,3 +71,76 @@ func (gc *GrpcClient) SendStatus(ctx context.Context, req *pb.UpdateJobStatusReq
 
 	return nil
 }
+
+// rpcOutcome — исход вызова для breaker'а. Сбоем считаются только ошибки
+// транспорта: ответ сервера с ошибкой (задача удалена, неверный запрос)
+// означает, что сервер доступен, а отмена контекста вызывающим (остановка
+// воркера) ничего не говорит о сервере.
+func rpcOutcome(ctx context.Context, err error) breaker.Outcome {
+	if err == nil {
+		return breaker.Success
+	}
+	if ctx.Err() != nil {
+		return breaker.Ignored
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return breaker.Failure
+	case codes.Canceled:
+		return breaker.Ignored
+	default:
+		return breaker.Success
+	}
+}
+
+func (gc *GrpcClient) SendHeartbeat(
+	ctx context.Context,
+	req *pb.JobHeartbeatRequest,
+) (*pb.JobHeartbeatResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, gc.Timeout)
+	defer cancel()
+
+	resp, err := gc.client.Heartbeat(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось отправить heartbeat через gRPC: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (gc *GrpcClient) SendRegistration(ctx context.Context, req *pb.WorkerRegistrationRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, gc.Timeout)
+	defer cancel()
+
+	resp, err := gc.client.RegisterWorker(ctx, req)
+	if err != nil {
+		return fmt.Errorf("не удалось зарегистрировать воркер через gRPC: %w", err)
+	}
+	if !resp.GetAccepted() {
+		return fmt.Errorf("gRPC сервер отклонил регистрацию воркера %s", req.GetWorkerId())
+	}
+
+	return nil
+}
+
+// SendProgress отправляет прогресс, только пока breaker управляющего сервиса
+// не разомкнут. Исход вызова на breaker не влияет: прогресс best-effort,
+// о доступности сервиса судят по отправке результатов.
+func (gc *GrpcClient) SendProgress(ctx context.Context, req *pb.JobProgressRequest) error {
+	if !gc.breaker.Ready() {
+		return fmt.Errorf("прогресс для %d задачи не отправлен: %w", req.GetJobId(), breaker.ErrOpen)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gc.Timeout)
+	defer cancel()
+
+	resp, err := gc.client.ReportJobProgress(ctx, req)
+	if err != nil {
+		return fmt.Errorf("не удалось отправить прогресс через gRPC: %w", err)
+	}
+	if !resp.GetSuccess() {
+		return fmt.Errorf("gRPC сервер отклонил прогресс для %d задачи", req.GetJobId())
+	}
+
+	return nil
+}
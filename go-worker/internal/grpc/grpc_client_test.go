@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/breaker"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusClient отвечает на UpdateJobStatus заданной ошибкой.
+type statusClient struct {
+	pb.JobStatusServiceClient
+	err error
+}
+
+func (c *statusClient) UpdateJobStatus(context.Context, *pb.UpdateJobStatusRequest, ...grpc.CallOption) (*pb.UpdateJobStatusResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &pb.UpdateJobStatusResponse{Success: true}, nil
+}
+
+func TestSendStatusOpensBreakerOnlyOnTransportErrors(t *testing.T) {
+	client := &statusClient{}
+	gc := &GrpcClient{
+		Timeout: time.Second,
+		client:  client,
+		breaker: breaker.New("grpc:test", breaker.Settings{
+			FailureThreshold: 1,
+			OpenTimeout:      time.Minute,
+			HalfOpenMaxCalls: 1,
+		}),
+	}
+	req := &pb.UpdateJobStatusRequest{JobId: 1}
+
+	// Ответы сервера с ошибкой: сервер доступен
+	for _, code := range []codes.Code{codes.NotFound, codes.InvalidArgument, codes.FailedPrecondition} {
+		client.err = status.Error(code, "rejected")
+		if err := gc.SendStatus(context.Background(), req); err == nil {
+			t.Fatalf("expected %s error", code)
+		}
+	}
+	if !gc.Healthy() {
+		t.Fatal("application errors must not open breaker")
+	}
+
+	// Остановка воркера отменяет контекст вызывающего
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.err = status.Error(codes.Canceled, "context canceled")
+	if err := gc.SendStatus(ctx, req); err == nil {
+		t.Fatal("expected cancellation error")
+	}
+	if !gc.Healthy() {
+		t.Fatal("cancellation by caller must not open breaker")
+	}
+
+	client.err = status.Error(codes.Unavailable, "connection refused")
+	if err := gc.SendStatus(context.Background(), req); err == nil {
+		t.Fatal("expected unavailable error")
+	}
+	if gc.Healthy() {
+		t.Error("transport error must open breaker")
+	}
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/lease"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Heartbeater продлевает аренду выполняемых задач на стороне Java сервиса
+// (реализует lease.Heartbeater).
+type Heartbeater struct {
+	grpcClient *GrpcClient
+	workerID   string
+}
+
+func NewHeartbeater(grpcClient *GrpcClient, workerID string) *Heartbeater {
+	return &Heartbeater{grpcClient: grpcClient, workerID: workerID}
+}
+
+// Heartbeat сообщает, что попытка attempt задачи jobID все еще выполняется
+// этим воркером. Возвращает true, если сервер отозвал аренду и выполнение
+// нужно прервать.
+func (h *Heartbeater) Heartbeat(ctx context.Context, jobID int64, attempt int, elapsed time.Duration) (bool, error) {
+	resp, err := h.grpcClient.SendHeartbeat(ctx, &pb.JobHeartbeatRequest{
+		JobId:     jobID,
+		WorkerId:  h.workerID,
+		ElapsedMs: elapsed.Milliseconds(),
+		Attempt:   int32(attempt),
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return false, fmt.Errorf("%w: %w", lease.ErrHeartbeatsUnsupported, err)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetLeaseRevoked(), nil
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// RegistrationClient сообщает Java сервису о возможностях и нагрузке воркера.
+type RegistrationClient struct {
+	grpcClient *GrpcClient
+}
+
+func NewRegistrationClient(grpcClient *GrpcClient) *RegistrationClient {
+	return &RegistrationClient{grpcClient: grpcClient}
+}
+
+func (c *RegistrationClient) Register(ctx context.Context, info models.WorkerInfo) error {
+	jobTypes := make([]string, 0, len(info.JobTypes))
+	for _, jt := range info.JobTypes {
+		jobTypes = append(jobTypes, string(jt))
+	}
+
+	return c.grpcClient.SendRegistration(ctx, &pb.WorkerRegistrationRequest{
+		WorkerId:   info.WorkerID,
+		Version:    info.Version,
+		JobTypes:   jobTypes,
+		PoolSize:   int32(info.PoolSize), //nolint:gosec // размер пула мал
+		InFlight:   int32(info.InFlight), //nolint:gosec // не больше размера пула
+		Queued:     int32(info.Queued),   //nolint:gosec // ограничено буфером канала
+		StartedAt:  info.StartedAt.Unix(),
+		ReportedAt: time.Now().Unix(),
+	})
+}
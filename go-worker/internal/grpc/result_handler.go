@@ -2,12 +2,14 @@ package grpc
 
 import (
 	"context"
-	"encoding/json"
-	"time"
+	"fmt"
 
 	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
 )
 
+// ResultHandler отправляет результаты в управляющий сервис: получатель
+// результатов "grpc" (см. worker.ResultSink).
 type ResultHandler struct {
 	grpcClient *GrpcClient
 }
@@ -16,50 +18,67 @@ func NewResultHandler(grpcClient *GrpcClient) *ResultHandler {
 	return &ResultHandler{grpcClient: grpcClient}
 }
 
-// Обработка успешного выполнения задачи.
-func (h *ResultHandler) HandleSuccess(jobID int64, result interface{}) error {
-	resultJSON, err := json.Marshal(result)
-	if err != nil {
-		return err
+func (h *ResultHandler) Name() string {
+	return "grpc"
+}
+
+// Send отправляет итоговый статус задачи.
+func (h *ResultHandler) Send(ctx context.Context, result models.JobResult) error {
+	switch result.Status {
+	case models.StatusCompleted:
+		return h.HandleSuccess(ctx, result)
+	case models.StatusFailed:
+		return h.HandleFailure(ctx, result)
+	default:
+		return fmt.Errorf("unexpected result status %q", result.Status)
 	}
+}
+
+// Healthy сообщает, доступен ли управляющий сервис для отправки результатов.
+func (h *ResultHandler) Healthy() bool {
+	return h.grpcClient.Healthy()
+}
 
+// Обработка успешного выполнения задачи. Результат уже закодирован
+// в JSON воркер-пулом и отправляется без повторного кодирования.
+func (h *ResultHandler) HandleSuccess(ctx context.Context, result models.JobResult) error {
 	req := &pb.UpdateJobStatusRequest{
-		JobId:  jobID,
-		Status: pb.UpdateJobStatusRequest_COMPLETED,
-		Result: string(resultJSON),
+		JobId:      result.JobID,
+		Status:     pb.UpdateJobStatusRequest_COMPLETED,
+		Result:     string(result.Result),
+		DurationMs: result.Duration.Milliseconds(),
+		Attempt:    int32(result.Attempt),
+		Logs:       result.Logs,
 	}
 
-	return h.grpcClient.SendStatus(context.Background(), req)
+	return h.grpcClient.SendStatus(ctx, req)
 }
 
 // Обработка ошибки выполнения задачи.
-func (h *ResultHandler) HandleFailure(jobID int64, err error) error {
+func (h *ResultHandler) HandleFailure(ctx context.Context, result models.JobResult) error {
 	req := &pb.UpdateJobStatusRequest{
-		JobId:        jobID,
+		JobId:        result.JobID,
 		Status:       pb.UpdateJobStatusRequest_FAILED,
 		Result:       "",
-		ErrorMessage: err.Error(),
-	}
-
-	return h.grpcClient.SendStatus(context.Background(), req)
-}
-
-// Специфичная логика для разных типов задач.
-func (h *ResultHandler) HandleHTTPResult(jobID int64, statusCode int, body string) error {
-	result := map[string]interface{}{
-		"status_code":  statusCode,
-		"body_length":  len(body),
-		"completed_at": time.Now().Unix(),
+		ErrorMessage: result.Error,
+		Retryable:    result.Retryable,
+		DurationMs:   result.Duration.Milliseconds(),
+		Attempt:      int32(result.Attempt),
+		Logs:         result.Logs,
 	}
 
-	return h.HandleSuccess(jobID, result)
+	return h.grpcClient.SendStatus(ctx, req)
 }
 
-func (h *ResultHandler) HandleImageResizeResult(jobID int64, newSize string) error {
-	result := map[string]interface{}{
-		"new_size":     newSize,
-		"completed_at": time.Now().Unix(),
+// Отправка промежуточного прогресса задачи.
+func (h *ResultHandler) HandleProgress(p models.JobProgress) error {
+	req := &pb.JobProgressRequest{
+		JobId:      p.JobID,
+		Percent:    p.Percent,
+		Stage:      p.Stage,
+		EtaSeconds: int64(p.ETA.Seconds()),
+		ReportedAt: p.ReportedAt.Unix(),
 	}
 
-	return h.HandleSuccess(jobID, result)
+	return h.grpcClient.SendProgress(context.Background(), req)
 }
@@ -0,0 +1,158 @@
+package grpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// serverCert выпускает сертификат сервера для host.
+func (ca *testCA) serverCert(t *testing.T, host string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func writeCA(t *testing.T, path string, ca *testCA, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, ca.pem, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handshake выполняет TLS handshake клиента с сервером, предъявляющим cert.
+func handshake(t *testing.T, client *tls.Config, cert tls.Certificate) error {
+	t.Helper()
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if tc, ok := conn.(*tls.Conn); ok {
+			_ = tc.Handshake()
+		}
+	}()
+
+	conn, err := tls.Dial("tcp", lis.Addr().String(), client)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func TestTLSConfigVerifiesServer(t *testing.T) {
+	ca := newTestCA(t, "platform-ca")
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writeCA(t, caFile, ca, time.Now().Add(-time.Minute))
+
+	tlsConfig, err := newTLSConfig(&config.Config{GrpcTLSCAFile: caFile, GrpcTLSServerName: "core.internal"})
+	if err != nil {
+		t.Fatalf("tls config: %v", err)
+	}
+
+	if err := handshake(t, tlsConfig, ca.serverCert(t, "core.internal")); err != nil {
+		t.Fatalf("expected trusted server to be accepted: %v", err)
+	}
+	if err := handshake(t, tlsConfig, ca.serverCert(t, "evil.internal")); err == nil {
+		t.Error("expected certificate for another host to be rejected")
+	}
+	other := newTestCA(t, "other-ca")
+	if err := handshake(t, tlsConfig, other.serverCert(t, "core.internal")); err == nil {
+		t.Error("expected certificate from untrusted CA to be rejected")
+	}
+}
+
+func TestTLSConfigPicksUpRotatedCA(t *testing.T) {
+	oldCA, newCA := newTestCA(t, "ca-2025"), newTestCA(t, "ca-2026")
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writeCA(t, caFile, oldCA, time.Now().Add(-time.Minute))
+
+	tlsConfig, err := newTLSConfig(&config.Config{GrpcTLSCAFile: caFile, GrpcTLSServerName: "core.internal"})
+	if err != nil {
+		t.Fatalf("tls config: %v", err)
+	}
+	if err := handshake(t, tlsConfig, newCA.serverCert(t, "core.internal")); err == nil {
+		t.Fatal("server with certificate from new CA must be rejected before rotation")
+	}
+
+	writeCA(t, caFile, newCA, time.Now())
+
+	if err := handshake(t, tlsConfig, newCA.serverCert(t, "core.internal")); err != nil {
+		t.Fatalf("expected rotated CA to be used without restart: %v", err)
+	}
+	if err := handshake(t, tlsConfig, oldCA.serverCert(t, "core.internal")); err == nil {
+		t.Error("expected certificate from replaced CA to be rejected")
+	}
+}
@@ -0,0 +1,106 @@
+package joblog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Capture накапливает записи лога одной задачи в текстовом формате,
+// не больше limit байт. При переполнении вытесняются самые старые строки:
+// для разбора упавшей задачи важнее записи перед ошибкой.
+type Capture struct {
+	limit int
+
+	mu      sync.Mutex
+	lines   []string
+	size    int
+	dropped int
+}
+
+func NewCapture(limit int) *Capture {
+	return &Capture{limit: limit}
+}
+
+// Write принимает одну запись slog.TextHandler.
+func (c *Capture) Write(p []byte) (int, error) {
+	line := string(p)
+	if len(line) > c.limit {
+		// Обрезка по границе символа, чтобы не разрезать многобайтовую руну
+		cut := c.limit - 1
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		line = line[:cut] + "\n"
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.lines) > 0 && c.size+len(line) > c.limit {
+		c.size -= len(c.lines[0])
+		c.lines = c.lines[1:]
+		c.dropped++
+	}
+	c.lines = append(c.lines, line)
+	c.size += len(line)
+
+	return len(p), nil
+}
+
+// String возвращает накопленные записи.
+func (c *Capture) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var b strings.Builder
+	if c.dropped > 0 {
+		fmt.Fprintf(&b, "... %d earlier lines dropped\n", c.dropped)
+	}
+	for _, line := range c.lines {
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// Tee возвращает логгер, который пишет и в logger, и в Capture.
+// В Capture попадают все уровни, включая debug, но без полей
+// корреляции logger: они и так известны по результату задачи.
+func (c *Capture) Tee(logger *slog.Logger) *slog.Logger {
+	return slog.New(teeHandler{
+		primary: logger.Handler(),
+		capture: slog.NewTextHandler(c, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	})
+}
+
+type teeHandler struct {
+	primary slog.Handler
+	capture slog.Handler
+}
+
+func (h teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.primary.Enabled(ctx, level) || h.capture.Enabled(ctx, level)
+}
+
+func (h teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	if h.primary.Enabled(ctx, r.Level) {
+		err = h.primary.Handle(ctx, r.Clone())
+	}
+	if h.capture.Enabled(ctx, r.Level) {
+		err = errors.Join(err, h.capture.Handle(ctx, r))
+	}
+	return err
+}
+
+func (h teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return teeHandler{primary: h.primary.WithAttrs(attrs), capture: h.capture.WithAttrs(attrs)}
+}
+
+func (h teeHandler) WithGroup(name string) slog.Handler {
+	return teeHandler{primary: h.primary.WithGroup(name), capture: h.capture.WithGroup(name)}
+}
@@ -0,0 +1,67 @@
+// Package joblog передает логгер задачи через контекст: каждая запись
+// executor'а и воркер-пула несет поля корреляции задачи (ID, тип, попытка,
+// воркер, партиция и offset Kafka, trace ID).
+package joblog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ctxKey struct{}
+
+// WithLogger кладет логгер задачи в контекст.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext возвращает логгер задачи из контекста.
+// Вне задачи возвращается slog.Default().
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
+
+// ForJob создает логгер задачи поверх slog.Default(). workerID — идентификатор
+// воркера (WORKER_ID), slot — номер горутины пула, выполняющей задачу.
+func ForJob(ctx context.Context, job models.Job, workerID string, slot int) *slog.Logger {
+	attrs := []any{
+		slog.Int64("job_id", job.ID),
+		slog.String("job_type", string(job.Type)),
+		slog.Int("attempt", job.Attempt),
+		slog.String("worker_id", workerID),
+		slog.Int("worker_slot", slot),
+		slog.String("kafka_topic", job.Topic),
+		slog.Int("kafka_partition", job.Partition),
+		slog.Int64("kafka_offset", job.Offset),
+	}
+	if job.Workflow != nil {
+		attrs = append(attrs, slog.String("workflow_step", job.Workflow.Step))
+	}
+	if job.Cron != "" {
+		attrs = append(attrs, slog.String("cron", job.Cron))
+	}
+	return slog.Default().With(withTraceID(ctx, attrs)...)
+}
+
+// ForResult создает логгер отправки результата задачи.
+func ForResult(ctx context.Context, result models.JobResult) *slog.Logger {
+	attrs := []any{
+		slog.Int64("job_id", result.JobID),
+		slog.Int("attempt", result.Attempt),
+	}
+	return slog.Default().With(withTraceID(ctx, attrs)...)
+}
+
+// withTraceID добавляет trace ID спана из ctx, если он есть.
+func withTraceID(ctx context.Context, attrs []any) []any {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+	}
+	return attrs
+}
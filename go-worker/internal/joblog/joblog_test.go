@@ -0,0 +1,51 @@
+package joblog_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/joblog"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+func TestCaptureKeepsLatestLines(t *testing.T) {
+	capture := joblog.NewCapture(200)
+	ctx := joblog.WithLogger(context.Background(), joblog.ForJob(context.Background(), models.Job{ID: 7}, "worker-a", 1))
+	logger := capture.Tee(joblog.FromContext(ctx))
+
+	for i := range 10 {
+		logger.Debug("step", slog.Int("n", i))
+	}
+
+	out := capture.String()
+	if !strings.HasPrefix(out, "... ") {
+		t.Fatalf("expected dropped lines marker, got %q", out)
+	}
+	if !strings.Contains(out, "n=9") {
+		t.Fatalf("expected latest line to be kept, got %q", out)
+	}
+	if strings.Contains(out, "n=0") {
+		t.Fatalf("expected oldest line to be dropped, got %q", out)
+	}
+	if strings.Contains(out, "job_id") {
+		t.Fatalf("correlation fields must not be captured, got %q", out)
+	}
+}
+
+func TestCaptureTruncatesOnRuneBoundary(t *testing.T) {
+	capture := joblog.NewCapture(8)
+	if _, err := capture.Write([]byte("ошибка соединения\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	out := capture.String()
+	if !utf8.ValidString(out) {
+		t.Fatalf("truncated line must stay valid UTF-8, got %q", out)
+	}
+	if out != "оши\n" {
+		t.Fatalf("unexpected truncated line %q", out)
+	}
+}
@@ -0,0 +1,50 @@
+package jobregistry
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// Middleware оборачивает Executor сквозной логикой (логирование, метрики,
+// rate limit и т.п.). jobType передается, чтобы middleware могла
+// подписывать метрики и логи типом задачи.
+type Middleware func(jobType models.JobType, next Executor) Executor
+
+// Chain — упорядоченный набор middleware: общие для всех типов задач
+// и дополнительные для отдельных типов. Первая добавленная middleware
+// оказывается внешней, общие всегда оборачивают специфичные для типа.
+type Chain struct {
+	global []Middleware
+	byType map[models.JobType][]Middleware
+}
+
+func NewChain() *Chain {
+	return &Chain{byType: make(map[models.JobType][]Middleware)}
+}
+
+// Use добавляет middleware для всех типов задач.
+func (c *Chain) Use(mw ...Middleware) *Chain {
+	c.global = append(c.global, mw...)
+	return c
+}
+
+// UseFor добавляет middleware только для jobType.
+func (c *Chain) UseFor(jobType models.JobType, mw ...Middleware) *Chain {
+	c.byType[jobType] = append(c.byType[jobType], mw...)
+	return c
+}
+
+// Wrap оборачивает executor типа jobType всей цепочкой.
+func (c *Chain) Wrap(jobType models.JobType, exec Executor) Executor {
+	if c == nil {
+		return exec
+	}
+
+	mws := make([]Middleware, 0, len(c.global)+len(c.byType[jobType]))
+	mws = append(mws, c.global...)
+	mws = append(mws, c.byType[jobType]...)
+
+	for i := len(mws) - 1; i >= 0; i-- {
+		exec = mws[i](jobType, exec)
+	}
+	return exec
+}
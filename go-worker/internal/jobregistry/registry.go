@@ -2,8 +2,10 @@ package jobregistry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
@@ -11,7 +13,9 @@ import (
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
 )
 
-type Executor func(ctx context.Context, payload string) (string, error)
+// Executor выполняет задачу и возвращает структурированный результат,
+// который воркер один раз кодирует в JSON.
+type Executor func(ctx context.Context, payload string) (any, error)
 type ExecutorFactory func(cfg *config.Config) Executor
 
 var (
@@ -22,24 +26,46 @@ var (
 	mu sync.RWMutex
 )
 
+// Register регистрирует executor встроенного типа задачи. protoType нужен
+// для старых сообщений без type_name; для типов без значения в enum
+// передается pb.JobTask_UNKNOWN_TYPE.
 func Register(jobType models.JobType, protoType pb.JobTask_TaskType, factory ExecutorFactory) {
 	mu.Lock()
 	defer mu.Unlock()
 
-	if !jobType.IsValid() {
-		panic(fmt.Sprintf("Задача типа %v не поддерживается", jobType))
+	if jobType == "" {
+		panic("пустой тип задачи")
 	}
-	if _, exists := jobTypeToProto[jobType]; exists {
+	if _, exists := executorFactories[jobType]; exists {
 		panic(fmt.Sprintf("%v уже зарегистрирован", jobType))
 	}
-	if _, exists := protoTypeToJobType[protoType]; exists {
-		panic(fmt.Sprintf("%v уже зарегистрирован", protoType))
+
+	if protoType != pb.JobTask_UNKNOWN_TYPE {
+		if _, exists := protoTypeToJobType[protoType]; exists {
+			panic(fmt.Sprintf("%v уже зарегистрирован", protoType))
+		}
+		jobTypeToProto[jobType] = protoType
+		protoTypeToJobType[protoType] = jobType
 	}
 
-	jobTypeToProto[jobType] = protoType
-	protoTypeToJobType[protoType] = jobType
+	executorFactories[jobType] = factory
+}
+
+// RegisterExternal регистрирует executor внешнего плагина.
+// Вызывается во время старта, поэтому конфликты возвращаются ошибкой, а не паникой.
+func RegisterExternal(jobType models.JobType, factory ExecutorFactory) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if jobType == "" {
+		return errors.New("пустой тип задачи")
+	}
+	if _, exists := executorFactories[jobType]; exists {
+		return fmt.Errorf("%v уже зарегистрирован", jobType)
+	}
 
 	executorFactories[jobType] = factory
+	return nil
 }
 
 func JobTypeFromProto(protoType pb.JobTask_TaskType) (models.JobType, error) {
@@ -53,34 +79,99 @@ func JobTypeFromProto(protoType pb.JobTask_TaskType) (models.JobType, error) {
 	return jobType, nil
 }
 
-func CreateExecutors(cfg *config.Config) map[models.JobType]Executor {
+// ResolveJobType определяет тип задачи из сообщения Kafka: по type_name,
+// если он задан, иначе по устаревшему enum.
+func ResolveJobType(protoType pb.JobTask_TaskType, typeName string) (models.JobType, error) {
+	if typeName == "" {
+		return JobTypeFromProto(protoType)
+	}
+
 	mu.RLock()
 	defer mu.RUnlock()
 
-	res := make(map[models.JobType]Executor, len(models.AllJobTypes))
+	jobType := models.JobType(typeName)
+	if _, exists := executorFactories[jobType]; !exists {
+		return "", fmt.Errorf("тип задачи %q не обслуживается этим воркером", typeName)
+	}
+	return jobType, nil
+}
 
-	for _, jt := range models.AllJobTypes {
-		factory, exists := executorFactories[jt]
-		if !exists {
-			slog.Warn("Фабрика для этого типа не найдена", "фабрика", jt)
-			continue
-		}
-		res[jt] = factory(cfg)
+// Unregister удаляет executor внешнего плагина (например, при остановке плагинов).
+func Unregister(jobType models.JobType) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	delete(executorFactories, jobType)
+	if protoType, ok := jobTypeToProto[jobType]; ok {
+		delete(jobTypeToProto, jobType)
+		delete(protoTypeToJobType, protoType)
+	}
+}
+
+// Names возвращает отсортированный список типов задач, которые может выполнить воркер.
+func Names() []models.JobType {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]models.JobType, 0, len(executorFactories))
+	for jt := range executorFactories {
+		names = append(names, jt)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// CreateExecutors создает executor'ы всех зарегистрированных типов
+// и оборачивает их цепочкой middleware. chain может быть nil.
+func CreateExecutors(cfg *config.Config, chain *Chain) map[models.JobType]Executor {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	res := make(map[models.JobType]Executor, len(executorFactories))
+	for jt, factory := range executorFactories {
+		res[jt] = chain.Wrap(jt, factory(cfg))
 	}
 	slog.Info(fmt.Sprintf("Создано %d executor'ов", len(res)))
 
 	return res
 }
 
-func ValidateAllTypesRegistered() error {
+// CreateExecutor создает executor одного типа, зарегистрированного после
+// CreateExecutors (например, плагина, запустившегося не с первой попытки).
+func CreateExecutor(cfg *config.Config, chain *Chain, jobType models.JobType) (Executor, bool) {
 	mu.RLock()
-	defer mu.RUnlock()
+	factory, ok := executorFactories[jobType]
+	mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return chain.Wrap(jobType, factory(cfg)), true
+}
+
+// Report — какие типы задач обслуживает воркер.
+type Report struct {
+	Served          []models.JobType // Все зарегистрированные имена
+	MissingBuiltins []models.JobType // Встроенные типы без executor'а
+}
+
+// BuildReport собирает отчет о зарегистрированных типах для лога при старте.
+func BuildReport() Report {
+	served := Names()
 
+	var missing []models.JobType
 	for _, jt := range models.AllJobTypes {
-		_, exists := executorFactories[jt]
-		if !exists {
-			return fmt.Errorf("%s не зарегестрирован", jt)
+		if !slices.Contains(served, jt) {
+			missing = append(missing, jt)
 		}
 	}
-	return nil
+
+	return Report{Served: served, MissingBuiltins: missing}
+}
+
+// Log пишет отчет в лог: список обслуживаемых типов и предупреждение о пропущенных.
+func (r Report) Log() {
+	slog.Info("Job types served by this worker", slog.Any("job_types", r.Served))
+	if len(r.MissingBuiltins) > 0 {
+		slog.Warn("Built-in job types without executor", slog.Any("job_types", r.MissingBuiltins))
+	}
 }
@@ -0,0 +1,93 @@
+package jobregistry_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+func noopFactory(*config.Config) jobregistry.Executor {
+	return func(context.Context, string) (any, error) { return nil, nil }
+}
+
+func TestResolveJobType(t *testing.T) {
+	jobregistry.Register(models.JobTypeSleep, pb.JobTask_SLEEP, noopFactory)
+	jobregistry.Register("PDF_RENDER", pb.JobTask_UNKNOWN_TYPE, noopFactory)
+
+	tests := []struct {
+		name      string
+		protoType pb.JobTask_TaskType
+		typeName  string
+		want      models.JobType
+		wantErr   bool
+	}{
+		{name: "legacy enum", protoType: pb.JobTask_SLEEP, want: models.JobTypeSleep},
+		{name: "type name", typeName: "PDF_RENDER", want: "PDF_RENDER"},
+		{name: "type name wins", protoType: pb.JobTask_SLEEP, typeName: "PDF_RENDER", want: "PDF_RENDER"},
+		{name: "unknown name", typeName: "NOPE", wantErr: true},
+		{name: "unknown enum", protoType: pb.JobTask_HTTP_GET, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jobregistry.ResolveJobType(tt.protoType, tt.typeName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	report := jobregistry.BuildReport()
+	if !slices.Contains(report.Served, "PDF_RENDER") {
+		t.Errorf("report must list PDF_RENDER: %+v", report)
+	}
+	if !slices.Contains(report.MissingBuiltins, models.JobTypeHttpGet) {
+		t.Errorf("report must list missing HTTP_GET: %+v", report)
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	trace := func(name string) jobregistry.Middleware {
+		return func(_ models.JobType, next jobregistry.Executor) jobregistry.Executor {
+			return func(ctx context.Context, payload string) (any, error) {
+				calls = append(calls, name)
+				return next(ctx, payload)
+			}
+		}
+	}
+
+	chain := jobregistry.NewChain().
+		UseFor("A", trace("typed")).
+		Use(trace("outer"), trace("inner"))
+
+	exec := chain.Wrap("A", func(context.Context, string) (any, error) {
+		calls = append(calls, "executor")
+		return nil, nil
+	})
+	if _, err := exec(context.Background(), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"outer", "inner", "typed", "executor"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("expected %v, got %v", want, calls)
+	}
+
+	calls = nil
+	other := chain.Wrap("B", func(context.Context, string) (any, error) { return nil, nil })
+	if _, err := other(context.Background(), ""); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(calls, []string{"outer", "inner"}) {
+		t.Errorf("typed middleware applied to other type: %v", calls)
+	}
+}
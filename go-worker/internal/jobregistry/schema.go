@@ -0,0 +1,111 @@
+package jobregistry
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// buildSchema строит JSON Schema payload'а по полям T и правилам `validate`.
+func buildSchema[T any](jobType models.JobType) map[string]any {
+	schema := structSchema(reflect.TypeFor[T]())
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = string(jobType)
+	return schema
+}
+
+func structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name := jsonFieldName(f)
+		if !f.IsExported() || name == "" {
+			continue
+		}
+
+		prop := typeSchema(f.Type)
+		for _, r := range parseRules(f.Tag.Get(validateTag)) {
+			applyRule(prop, f.Type, r)
+			if r.name == "required" {
+				required = append(required, name)
+			}
+		}
+		properties[name] = prop
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() { //nolint:exhaustive // прочие типы описываются без ограничения типа
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object"}
+	case reflect.Struct:
+		return structSchema(t)
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	default:
+		return map[string]any{}
+	}
+}
+
+func applyRule(prop map[string]any, t reflect.Type, r rule) {
+	switch r.name {
+	case "min", "max":
+		bound, err := strconv.ParseFloat(r.param, 64)
+		if err != nil {
+			return
+		}
+		key := map[string]string{"min": "minimum", "max": "maximum"}[r.name]
+		if t.Kind() == reflect.String {
+			key = map[string]string{"min": "minLength", "max": "maxLength"}[r.name]
+		} else if t.Kind() == reflect.Slice {
+			key = map[string]string{"min": "minItems", "max": "maxItems"}[r.name]
+		}
+		prop[key] = bound
+	case "url":
+		prop["format"] = "uri"
+		prop["pattern"] = "^https?://"
+	case "oneof":
+		prop["enum"] = strings.Fields(r.param)
+	case "required":
+		if t.Kind() == reflect.String {
+			prop["minLength"] = 1
+		}
+	}
+}
+
+// jsonTypeName — название JSON-типа для сообщений об ошибках разбора.
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return "valid"
+	}
+	if s, ok := typeSchema(t)["type"].(string); ok {
+		return "of type " + s
+	}
+	return "valid"
+}
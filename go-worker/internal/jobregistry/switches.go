@@ -0,0 +1,38 @@
+package jobregistry
+
+import (
+	"sync"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// Switches хранит типы задач, выключенные оператором на этом воркере.
+// По умолчанию все типы включены.
+type Switches struct {
+	mu       sync.RWMutex
+	disabled map[models.JobType]bool
+}
+
+func NewSwitches() *Switches {
+	return &Switches{disabled: make(map[models.JobType]bool)}
+}
+
+// Set включает или выключает тип задачи.
+func (s *Switches) Set(jobType models.JobType, enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if enabled {
+		delete(s.disabled, jobType)
+	} else {
+		s.disabled[jobType] = true
+	}
+}
+
+// Enabled сообщает, включен ли тип задачи.
+func (s *Switches) Enabled(jobType models.JobType) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return !s.disabled[jobType]
+}
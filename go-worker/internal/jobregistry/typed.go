@@ -0,0 +1,123 @@
+package jobregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// TypedExecutor — executor, получающий уже разобранный и провалидированный payload.
+type TypedExecutor[T any] func(ctx context.Context, payload *T) (any, error)
+
+// TypedExecutorFactory создает TypedExecutor из конфигурации.
+type TypedExecutorFactory[T any] func(cfg *config.Config) TypedExecutor[T]
+
+var schemas = make(map[models.JobType]map[string]any)
+
+// RegisterTyped регистрирует executor с типизированным payload. Реестр сам
+// разбирает JSON в T и проверяет правила из тегов `validate` до запуска executor'а,
+// а по T строит JSON Schema для типа задачи.
+func RegisterTyped[T any](jobType models.JobType, protoType pb.JobTask_TaskType, factory TypedExecutorFactory[T]) {
+	schema := buildSchema[T](jobType)
+
+	Register(jobType, protoType, func(cfg *config.Config) Executor {
+		typed := factory(cfg)
+		return func(ctx context.Context, payload string) (any, error) {
+			p, err := Decode[T](payload)
+			if err != nil {
+				return nil, err
+			}
+			return typed(ctx, p)
+		}
+	})
+
+	mu.Lock()
+	schemas[jobType] = schema
+	mu.Unlock()
+}
+
+// Decode разбирает JSON payload в T и валидирует его.
+// Ошибки возвращаются как *ValidationError с перечнем полей.
+func Decode[T any](payload string) (*T, error) {
+	var p T
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(payload)))
+	if err := dec.Decode(&p); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return nil, &ValidationError{Fields: []FieldError{{
+				Field:   typeErr.Field,
+				Message: fmt.Sprintf("must be %s", jsonTypeName(typeErr.Type)),
+			}}}
+		}
+		return nil, &ValidationError{Fields: []FieldError{{
+			Field:   "",
+			Message: "invalid JSON: " + err.Error(),
+		}}}
+	}
+
+	// Payload — ровно одно JSON значение: данные после него — признак битого сообщения
+	if err := dec.Decode(&json.RawMessage{}); !errors.Is(err, io.EOF) {
+		return nil, &ValidationError{Fields: []FieldError{{
+			Field:   "",
+			Message: "invalid JSON: unexpected data after payload",
+		}}}
+	}
+
+	if fields := validateStruct(&p); len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+	return &p, nil
+}
+
+// Schema возвращает JSON Schema payload'а для типа задачи, зарегистрированного через RegisterTyped.
+func Schema(jobType models.JobType) (map[string]any, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	s, ok := schemas[jobType]
+	return s, ok
+}
+
+// Schemas возвращает JSON Schema всех типизированных типов задач.
+func Schemas() map[models.JobType]map[string]any {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	res := make(map[models.JobType]map[string]any, len(schemas))
+	for jt, s := range schemas {
+		res[jt] = s
+	}
+	return res
+}
+
+// FieldError — ошибка конкретного поля payload'а.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError — payload не прошел разбор или валидацию.
+type ValidationError struct {
+	Fields []FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		if f.Field == "" {
+			parts = append(parts, f.Message)
+			continue
+		}
+		parts = append(parts, f.Field+": "+f.Message)
+	}
+	return "invalid payload: " + strings.Join(parts, "; ")
+}
@@ -0,0 +1,93 @@
+package jobregistry_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+type testPayload struct {
+	URL   string `json:"url" validate:"required,url"`
+	Width int    `json:"width" validate:"required,min=1,max=100"`
+	Mode  string `json:"mode" validate:"oneof=fast slow"`
+}
+
+func TestDecodeValidatesFields(t *testing.T) {
+	p, err := jobregistry.Decode[testPayload](`{"url":"https://example.com","width":10,"mode":"fast"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Width != 10 {
+		t.Errorf("expected width 10, got %d", p.Width)
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		field   string
+	}{
+		{name: "missing url", payload: `{"width":10}`, field: "url"},
+		{name: "bad url", payload: `{"url":"ftp://x","width":10}`, field: "url"},
+		{name: "zero width", payload: `{"url":"http://x.io"}`, field: "width"},
+		{name: "too wide", payload: `{"url":"http://x.io","width":101}`, field: "width"},
+		{name: "bad enum", payload: `{"url":"http://x.io","width":1,"mode":"warp"}`, field: "mode"},
+		{name: "wrong type", payload: `{"url":"http://x.io","width":"wide"}`, field: "width"},
+		{name: "not json", payload: `{`, field: ""},
+		{name: "trailing data", payload: `{"url":"http://x.io","width":1} {"width":2}`, field: ""},
+		{name: "trailing brace", payload: `{"url":"http://x.io","width":1}}`, field: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jobregistry.Decode[testPayload](tt.payload)
+
+			var verr *jobregistry.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if verr.Fields[0].Field != tt.field {
+				t.Errorf("expected error for field %q, got %+v", tt.field, verr.Fields)
+			}
+		})
+	}
+}
+
+func TestDecodeValidatesNestedStructs(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		field   string
+	}{
+		{
+			name:    "step without name",
+			payload: `{"steps":[{"name":"a","type":"SLEEP"},{"type":"SLEEP"}]}`,
+			field:   "steps[1].name",
+		},
+		{
+			name:    "bad step policy",
+			payload: `{"steps":[{"name":"a","type":"SLEEP","on_failure":"retry"}]}`,
+			field:   "steps[0].on_failure",
+		},
+		{
+			name:    "compensation without type",
+			payload: `{"steps":[{"name":"a","type":"SLEEP","compensate":{}}]}`,
+			field:   "steps[0].compensate.type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jobregistry.Decode[models.PayloadWorkflow](tt.payload)
+
+			var verr *jobregistry.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if len(verr.Fields) != 1 || verr.Fields[0].Field != tt.field {
+				t.Errorf("expected error for field %q, got %+v", tt.field, verr.Fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package jobregistry
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Поддерживаемые правила тега `validate` (через запятую). Правила вложенных
+// структур (в том числе элементов срезов) проверяются рекурсивно:
+//
+//	required  — значение не нулевое (непустая строка, ненулевое число)
+//	min=N     — для чисел значение >= N, для строк и срезов длина >= N
+//	max=N     — для чисел значение <= N, для строк и срезов длина <= N
+//	url       — абсолютный http(s) URL
+//	oneof=a b — значение из перечисленных через пробел
+const validateTag = "validate"
+
+type rule struct {
+	name  string
+	param string
+}
+
+func parseRules(tag string) []rule {
+	var rules []rule
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules
+}
+
+// jsonFieldName возвращает имя поля в JSON или "" для полей, исключенных из JSON.
+func jsonFieldName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+func validateStruct(v any) []FieldError {
+	return validateValue(reflect.ValueOf(v), "")
+}
+
+// validateValue проверяет правила полей структуры и рекурсивно — вложенных структур,
+// в том числе по указателю и в срезах. path — путь к значению в JSON,
+// например steps[1].compensate.type.
+func validateValue(rv reflect.Value, path string) []FieldError {
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() { //nolint:exhaustive // в остальных типах нет вложенных правил
+	case reflect.Struct:
+	case reflect.Slice, reflect.Array:
+		elem := rv.Type().Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			return nil
+		}
+		var errs []FieldError
+		for i := range rv.Len() {
+			errs = append(errs, validateValue(rv.Index(i), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return errs
+	default:
+		return nil
+	}
+
+	var errs []FieldError
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		f := rt.Field(i)
+		name := jsonFieldName(f)
+		if !f.IsExported() || name == "" {
+			continue
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+
+		valid := true
+		for _, r := range parseRules(f.Tag.Get(validateTag)) {
+			if msg := checkRule(rv.Field(i), r); msg != "" {
+				errs = append(errs, FieldError{Field: name, Message: msg})
+				valid = false
+				break
+			}
+		}
+		if valid {
+			errs = append(errs, validateValue(rv.Field(i), name)...)
+		}
+	}
+	return errs
+}
+
+func checkRule(v reflect.Value, r rule) string {
+	switch r.name {
+	case "required":
+		if v.IsZero() {
+			return "is required"
+		}
+	case "min", "max":
+		return checkBound(v, r)
+	case "url":
+		if v.Kind() == reflect.String && !v.IsZero() && !isHTTPURL(v.String()) {
+			return "must be an absolute http(s) URL"
+		}
+	case "oneof":
+		options := strings.Fields(r.param)
+		if !v.IsZero() && !slices.Contains(options, fmt.Sprint(v.Interface())) {
+			return "must be one of: " + strings.Join(options, ", ")
+		}
+	}
+	return ""
+}
+
+func checkBound(v reflect.Value, r rule) string {
+	bound, err := strconv.ParseFloat(r.param, 64)
+	if err != nil {
+		return ""
+	}
+
+	var actual float64
+	var what string
+	switch v.Kind() { //nolint:exhaustive // остальные типы правила min/max не проверяют
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual, what = float64(v.Int()), "value"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual, what = float64(v.Uint()), "value"
+	case reflect.Float32, reflect.Float64:
+		actual, what = v.Float(), "value"
+	case reflect.String, reflect.Slice, reflect.Map:
+		actual, what = float64(v.Len()), "length"
+	default:
+		return ""
+	}
+
+	if r.name == "min" && actual < bound {
+		return fmt.Sprintf("%s must be >= %s", what, r.param)
+	}
+	if r.name == "max" && actual > bound {
+		return fmt.Sprintf("%s must be <= %s", what, r.param)
+	}
+	return ""
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
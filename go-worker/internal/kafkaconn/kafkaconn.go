@@ -0,0 +1,129 @@
+// Package kafkaconn собирает параметры подключения к Kafka (TLS, SASL,
+// таймауты) из конфигурации. Используется и читателем, и писателями,
+// чтобы настройки безопасности задавались в одном месте.
+package kafkaconn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// Dialer возвращает Dialer для kafka.Reader.
+func Dialer(cfg *config.Config) (*kafka.Dialer, error) {
+	tlsConfig, mechanism, err := security(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafka.Dialer{
+		ClientID:      cfg.KafkaClientID,
+		Timeout:       cfg.KafkaDialTimeout,
+		DualStack:     true,
+		TLS:           tlsConfig,
+		SASLMechanism: mechanism,
+	}, nil
+}
+
+// Transport возвращает Transport для kafka.Writer с теми же настройками, что и Dialer.
+func Transport(cfg *config.Config) (*kafka.Transport, error) {
+	tlsConfig, mechanism, err := security(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafka.Transport{
+		ClientID:    cfg.KafkaClientID,
+		DialTimeout: cfg.KafkaDialTimeout,
+		TLS:         tlsConfig,
+		SASL:        mechanism,
+	}, nil
+}
+
+// GroupBalancers возвращает стратегию распределения партиций по KAFKA_REBALANCE_STRATEGY.
+func GroupBalancers(cfg *config.Config) []kafka.GroupBalancer {
+	switch cfg.KafkaRebalanceStrategy {
+	case "roundrobin":
+		return []kafka.GroupBalancer{kafka.RoundRobinGroupBalancer{}}
+	default:
+		return []kafka.GroupBalancer{kafka.RangeGroupBalancer{}}
+	}
+}
+
+// StartOffset возвращает смещение для новой consumer group по KAFKA_START_OFFSET.
+func StartOffset(cfg *config.Config) int64 {
+	if cfg.KafkaStartOffset == "earliest" {
+		return kafka.FirstOffset
+	}
+	return kafka.LastOffset
+}
+
+func security(cfg *config.Config) (*tls.Config, sasl.Mechanism, error) {
+	tlsConfig, err := TLSConfig(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kafka tls: %w", err)
+	}
+	mechanism, err := SASLMechanism(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kafka sasl: %w", err)
+	}
+	return tlsConfig, mechanism, nil
+}
+
+// TLSConfig возвращает nil, если TLS выключен.
+func TLSConfig(cfg *config.Config) (*tls.Config, error) {
+	if !cfg.KafkaTLSEnabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.KafkaTLSInsecureSkipVerify, //nolint:gosec // явно включается для тестовых стендов
+	}
+
+	if cfg.KafkaTLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.KafkaTLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.KafkaTLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.KafkaTLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.KafkaTLSCertFile, cfg.KafkaTLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// SASLMechanism возвращает nil для KAFKA_SASL_MECHANISM=none.
+func SASLMechanism(cfg *config.Config) (sasl.Mechanism, error) {
+	switch cfg.KafkaSASLMechanism {
+	case "", "none":
+		return nil, nil
+	case "plain":
+		return plain.Mechanism{Username: cfg.KafkaSASLUsername, Password: cfg.KafkaSASLPassword}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, cfg.KafkaSASLUsername, cfg.KafkaSASLPassword)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, cfg.KafkaSASLUsername, cfg.KafkaSASLPassword)
+	default:
+		return nil, errors.New("unsupported mechanism " + cfg.KafkaSASLMechanism)
+	}
+}
@@ -0,0 +1,54 @@
+package kafkaconn_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/kafkaconn"
+)
+
+func TestSASLMechanism(t *testing.T) {
+	tests := []struct {
+		mechanism string
+		want      string
+	}{
+		{mechanism: "none"},
+		{mechanism: "plain", want: "PLAIN"},
+		{mechanism: "scram-sha-256", want: "SCRAM-SHA-256"},
+		{mechanism: "scram-sha-512", want: "SCRAM-SHA-512"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mechanism, func(t *testing.T) {
+			m, err := kafkaconn.SASLMechanism(&config.Config{
+				KafkaSASLMechanism: tt.mechanism,
+				KafkaSASLUsername:  "user",
+				KafkaSASLPassword:  "pass",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == "" {
+				if m != nil {
+					t.Fatalf("expected no mechanism, got %s", m.Name())
+				}
+				return
+			}
+			if m == nil || m.Name() != tt.want {
+				t.Fatalf("expected %s, got %v", tt.want, m)
+			}
+		})
+	}
+}
+
+func TestDialerFailsOnMissingCA(t *testing.T) {
+	_, err := kafkaconn.Dialer(&config.Config{
+		KafkaTLSEnabled:    true,
+		KafkaTLSCAFile:     filepath.Join(t.TempDir(), "missing.pem"),
+		KafkaSASLMechanism: "none",
+	})
+	if err == nil {
+		t.Fatal("expected error for missing CA file")
+	}
+}
@@ -0,0 +1,17 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrHeartbeatsUnsupported возвращает Heartbeater, если сервер не реализует
+// продление аренды (старая версия управляющего сервиса).
+var ErrHeartbeatsUnsupported = errors.New("heartbeats are not supported by the server")
+
+// Heartbeater продлевает аренду выполняемой попытки задачи.
+// Возвращает true, если аренда отозвана и выполнение нужно прервать.
+type Heartbeater interface {
+	Heartbeat(ctx context.Context, jobID int64, attempt int, elapsed time.Duration) (bool, error)
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var executionDuration = promauto.With(metrics.Registry).NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "executor",
+	Name:      "duration_seconds",
+	Help:      "Executor run time by job type and outcome: success, error or retryable_error.",
+	Buckets:   prometheus.ExponentialBuckets(0.005, 4, 10),
+}, []string{"job_type", "outcome"})
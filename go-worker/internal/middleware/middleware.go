@@ -0,0 +1,161 @@
+// Package middleware содержит встроенные middleware для executor'ов:
+// они подключаются цепочкой jobregistry.Chain при старте воркера.
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/joblog"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/ratelimit"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Recover превращает панику executor'а в ошибку задачи, чтобы она
+// не уронила воркер целиком.
+func Recover() jobregistry.Middleware {
+	return func(_ models.JobType, next jobregistry.Executor) jobregistry.Executor {
+		return func(ctx context.Context, payload string) (result any, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					joblog.FromContext(ctx).Error("Executor panicked",
+						slog.Any("panic", r),
+						slog.String("stack", string(debug.Stack())),
+					)
+					result, err = nil, fmt.Errorf("executor panic: %v", r)
+				}
+			}()
+			return next(ctx, payload)
+		}
+	}
+}
+
+// Tracing создает спан выполнения задачи с типом задачи в имени.
+// Исходящие HTTP и gRPC вызовы executor'а становятся его дочерними спанами.
+func Tracing() jobregistry.Middleware {
+	return func(jobType models.JobType, next jobregistry.Executor) jobregistry.Executor {
+		name := "job.execute " + string(jobType)
+		return func(ctx context.Context, payload string) (any, error) {
+			ctx, span := tracing.Tracer().Start(ctx, name,
+				trace.WithAttributes(tracing.AttrJobType.String(string(jobType))),
+			)
+			defer span.End()
+
+			result, err := next(ctx, payload)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return result, err
+		}
+	}
+}
+
+// Logging пишет в лог задачи начало и окончание выполнения.
+func Logging() jobregistry.Middleware {
+	return func(_ models.JobType, next jobregistry.Executor) jobregistry.Executor {
+		return func(ctx context.Context, payload string) (any, error) {
+			logger := joblog.FromContext(ctx)
+			logger.Debug("Executor started")
+			start := time.Now()
+
+			result, err := next(ctx, payload)
+
+			attrs := []any{slog.Duration("duration", time.Since(start))}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+			logger.Debug("Executor finished", attrs...)
+
+			return result, err
+		}
+	}
+}
+
+// Metrics считает выполнения и их длительность по типу задачи и результату.
+func Metrics() jobregistry.Middleware {
+	return func(jobType models.JobType, next jobregistry.Executor) jobregistry.Executor {
+		return func(ctx context.Context, payload string) (any, error) {
+			start := time.Now()
+			result, err := next(ctx, payload)
+
+			outcome := "success"
+			switch {
+			case models.IsRetryable(err):
+				outcome = "retryable_error"
+			case err != nil:
+				outcome = "error"
+			}
+			executionDuration.WithLabelValues(string(jobType), outcome).Observe(time.Since(start).Seconds())
+
+			return result, err
+		}
+	}
+}
+
+// RateLimit ждет токен по типу задачи в пределах ее дедлайна и кладет
+// Limiter в контекст для лимитов по хостам (ratelimit.WaitHost).
+// При nil limiter ограничений нет.
+func RateLimit(limiter *ratelimit.Limiter) jobregistry.Middleware {
+	return func(jobType models.JobType, next jobregistry.Executor) jobregistry.Executor {
+		if limiter == nil {
+			return next
+		}
+		return func(ctx context.Context, payload string) (any, error) {
+			ctx = ratelimit.WithLimiter(ctx, limiter)
+			if err := limiter.WaitJobType(ctx, jobType); err != nil {
+				return nil, err
+			}
+			return next(ctx, payload)
+		}
+	}
+}
+
+// Switch отклоняет задачи типов, выключенных оператором, временной ошибкой:
+// задача будет повторена на другом воркере.
+func Switch(switches *jobregistry.Switches) jobregistry.Middleware {
+	return func(jobType models.JobType, next jobregistry.Executor) jobregistry.Executor {
+		return func(ctx context.Context, payload string) (any, error) {
+			if !switches.Enabled(jobType) {
+				return nil, models.Retryable(fmt.Errorf("job type %s is disabled on this worker", jobType))
+			}
+			return next(ctx, payload)
+		}
+	}
+}
+
+// Timeout ограничивает время выполнения задачи типа сильнее, чем MAX_JOB_TIMEOUT.
+func Timeout(d time.Duration) jobregistry.Middleware {
+	return func(_ models.JobType, next jobregistry.Executor) jobregistry.Executor {
+		return func(ctx context.Context, payload string) (any, error) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx, payload)
+		}
+	}
+}
+
+// Concurrency ограничивает число одновременно выполняемых задач типа.
+// Задача ждет свободного слота в пределах своего дедлайна.
+func Concurrency(n int) jobregistry.Middleware {
+	return func(jobType models.JobType, next jobregistry.Executor) jobregistry.Executor {
+		slots := make(chan struct{}, n)
+		return func(ctx context.Context, payload string) (any, error) {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, fmt.Errorf("concurrency limit for %s: %w", jobType, ctx.Err())
+			}
+			defer func() { <-slots }()
+
+			return next(ctx, payload)
+		}
+	}
+}
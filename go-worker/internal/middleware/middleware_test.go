@@ -0,0 +1,19 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/middleware"
+)
+
+func TestRecoverTurnsPanicIntoError(t *testing.T) {
+	exec := middleware.Recover()("TEST", func(context.Context, string) (any, error) {
+		panic("boom")
+	})
+
+	result, err := exec(context.Background(), "")
+	if err == nil || result != nil {
+		t.Fatalf("expected error from panicking executor, got %v, %v", result, err)
+	}
+}
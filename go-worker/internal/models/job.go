@@ -3,6 +3,8 @@ package models
 import (
 	"encoding/json"
 	"errors"
+	"strconv"
+	"time"
 )
 
 // JobType определяет тип задачи.
@@ -12,9 +14,14 @@ const (
 	JobTypeHttpGet     JobType = "HTTP_GET"
 	JobTypeImageResize JobType = "IMAGE_RESIZE"
 	JobTypeSleep       JobType = "SLEEP"
+
+	// JobTypeWorkflow — задача-оркестратор: ее payload описывает шаги workflow
+	// (PayloadWorkflow), которые воркер публикует в Kafka как отдельные задачи.
+	JobTypeWorkflow JobType = "WORKFLOW"
 )
 
-// AllJobTypes - полный список всех поддерживаемых типов задач.
+// AllJobTypes - встроенные типы задач, которые есть в proto enum TaskType.
+// Остальные типы (например, из плагинов) адресуются по JobTask.type_name.
 var AllJobTypes = []JobType{
 	JobTypeHttpGet,
 	JobTypeImageResize,
@@ -37,43 +44,154 @@ type Job struct {
 	Type      JobType `json:"type"`
 	Payload   string  `json:"payload"`
 	CreatedAt int64   `json:"created_at"` // Unix timestamp
+	Attempt   int     `json:"attempt"`
+
+	// Контекст трассировки (W3C traceparent) и время получения из Kafka
+	TraceHeaders map[string]string `json:"trace_headers,omitempty"`
+	ReceivedAt   time.Time         `json:"received_at"`
+
+	// Положение сообщения в Kafka, для корреляции логов и настроек топика
+	Topic     string `json:"kafka_topic,omitempty"`
+	Partition int    `json:"kafka_partition"`
+	Offset    int64  `json:"kafka_offset"`
+
+	// Шаг workflow; ID при этом — идентификатор задачи WORKFLOW
+	Workflow *WorkflowStepRef `json:"workflow,omitempty"`
+
+	// Время, раньше которого задачу нельзя выполнять (JobTask.not_before)
+	NotBefore time.Time `json:"not_before,omitzero"`
+	// Имя записи из секции cron, если задача запущена по расписанию: у нее
+	// нет записи в управляющем сервисе, ID — 0, CreatedAt — время срабатывания
+	Cron string `json:"cron,omitempty"`
+}
+
+// WorkflowStepRef — ссылка на шаг workflow, который выполняет задача.
+type WorkflowStepRef struct {
+	Step string `json:"step"`
+	Run  int    `json:"run"` // Попытка задачи WORKFLOW, в которой запущен шаг
+}
+
+// Key — ключ задачи для дедупликации: "ID#попытка" (задачу, переназначенную
+// управляющим сервисом с новой попыткой, нужно выполнить заново), для шага
+// workflow — "ID/запуск/шаг" (шаг нового запуска workflow — другая задача),
+// для задачи по расписанию — "cron/имя/время срабатывания".
+func (j Job) Key() string {
+	if j.Cron != "" {
+		return "cron/" + j.Cron + "/" + strconv.FormatInt(j.CreatedAt, 10)
+	}
+	key := strconv.FormatInt(j.ID, 10)
+	if j.Workflow != nil {
+		return key + "/" + strconv.Itoa(j.Workflow.Run) + "/" + j.Workflow.Step
+	}
+	return key + "#" + strconv.Itoa(j.Attempt)
 }
 
 // JobResult — результат выполнения задачи.
 type JobResult struct {
-	JobID  int64
-	Status JobStatus
-	Result string
-	Error  string
+	JobID     int64           `json:"job_id"`
+	Type      JobType         `json:"type,omitempty"`
+	Status    JobStatus       `json:"status"`
+	Result    json.RawMessage `json:"result,omitempty"` // JSON результата executor'а
+	Error     string          `json:"error,omitempty"`
+	Retryable bool            `json:"retryable,omitempty"`
+	Duration  time.Duration   `json:"duration"`
+	Attempt   int             `json:"attempt"`
+	Logs      string          `json:"logs,omitempty"` // Лог выполнения, если включен JOB_LOG_CAPTURE
+
+	// Контекст трассировки задачи для спана отправки результата
+	TraceHeaders map[string]string `json:"trace_headers,omitempty"`
+}
+
+// RetryableError помечает временную ошибку, после которой задачу можно повторить.
+type RetryableError struct {
+	Err error
+}
+
+func (e *RetryableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryableError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable оборачивает ошибку в RetryableError.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &RetryableError{Err: err}
+}
+
+// IsRetryable проверяет, помечена ли ошибка как временная.
+func IsRetryable(err error) bool {
+	var re *RetryableError
+	return errors.As(err, &re)
+}
+
+// JobProgress — промежуточный прогресс выполнения задачи.
+type JobProgress struct {
+	JobID      int64
+	Percent    float64
+	Stage      string
+	ETA        time.Duration
+	ReportedAt time.Time
+}
+
+// WorkerInfo — возможности и текущая нагрузка экземпляра воркера.
+type WorkerInfo struct {
+	WorkerID  string
+	Version   string
+	JobTypes  []JobType
+	PoolSize  int
+	InFlight  int
+	Queued    int
+	StartedAt time.Time
 }
 
+// Результаты встроенных executor'ов.
+
+// HttpGetResult — результат HTTP_GET.
+type HttpGetResult struct {
+	StatusCode  int   `json:"status_code"`
+	BodyLength  int   `json:"body_length"`
+	CompletedAt int64 `json:"completed_at"` // Unix timestamp
+}
+
+// ImageResizeResult — результат IMAGE_RESIZE.
+type ImageResizeResult struct {
+	Width       int   `json:"width"`
+	Height      int   `json:"height"`
+	Simulated   bool  `json:"simulated"`
+	CompletedAt int64 `json:"completed_at"` // Unix timestamp
+}
+
+// SleepResult — результат SLEEP.
+type SleepResult struct {
+	SleptMs int `json:"slept_ms"`
+}
+
+// Payload-структуры разбираются и валидируются в jobregistry.RegisterTyped
+// по правилам из тега `validate`.
+
 // PayloadHttpGet — структура payload для HTTP задач.
 type PayloadHttpGet struct {
-	URL string `json:"url"`
+	URL string `json:"url" validate:"required,url"`
 }
 
 // PayloadImageResize — структура payload для картинок.
 type PayloadImageResize struct {
-	ImageURL string `json:"image_url"`
-	Width    int    `json:"width"`
-	Height   int    `json:"height"`
+	ImageURL string `json:"image_url" validate:"required,url"`
+	Width    int    `json:"width" validate:"required,min=1,max=10000"`
+	Height   int    `json:"height" validate:"required,min=1,max=10000"`
 }
 
 // PayloadSleep — структура payload для sleep.
 type PayloadSleep struct {
-	DurationMs int `json:"duration_ms"`
-}
-
-// ParsePayload — вспомогательный метод.
-func ParsePayload[T any](payloadJSON string) (*T, error) {
-	var t T
-	if err := json.Unmarshal([]byte(payloadJSON), &t); err != nil {
-		return nil, errors.New("invalid payload format: " + err.Error())
-	}
-	return &t, nil
+	DurationMs int `json:"duration_ms" validate:"min=0,max=3600000"`
 }
 
-// IsValid проверяет, что тип задачи поддерживается.
+// IsValid проверяет, что тип задачи относится к встроенным.
 func (jt JobType) IsValid() bool {
 	for _, validType := range AllJobTypes {
 		if jt == validType {
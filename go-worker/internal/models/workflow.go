@@ -0,0 +1,43 @@
+package models
+
+// WorkflowFailurePolicy — что делать, если шаг workflow завершился ошибкой.
+type WorkflowFailurePolicy string
+
+const (
+	// WorkflowAbort — не запускать новые шаги, workflow завершается ошибкой.
+	WorkflowAbort WorkflowFailurePolicy = "abort"
+	// WorkflowContinue — считать шаг пройденным: зависимые шаги запускаются без его выхода.
+	WorkflowContinue WorkflowFailurePolicy = "continue"
+	// WorkflowCompensate — как abort, затем компенсирующие действия
+	// завершенных шагов в обратном порядке.
+	WorkflowCompensate WorkflowFailurePolicy = "compensate"
+)
+
+// PayloadWorkflow — структура payload для WORKFLOW: шаги и зависимости между ними.
+type PayloadWorkflow struct {
+	Steps     []WorkflowStep        `json:"steps" validate:"required,min=1,max=100"`
+	OnFailure WorkflowFailurePolicy `json:"on_failure" validate:"oneof=abort continue compensate"` // По умолчанию abort
+}
+
+// WorkflowStep — шаг workflow. Запускается, когда завершены все шаги из DependsOn.
+type WorkflowStep struct {
+	Name      string         `json:"name" validate:"required"`
+	Type      JobType        `json:"type" validate:"required"`
+	Payload   map[string]any `json:"payload,omitempty"`
+	DependsOn []string       `json:"depends_on,omitempty"`
+
+	// Поля payload из результатов предыдущих шагов: поле -> "шаг.путь.в.результате"
+	Inputs map[string]string `json:"inputs,omitempty"`
+
+	OnFailure   WorkflowFailurePolicy `json:"on_failure,omitempty" validate:"oneof=abort continue compensate"` // По умолчанию — политика workflow
+	MaxAttempts int                   `json:"max_attempts,omitempty" validate:"min=0,max=10"`                  // Попыток при временной ошибке, 0 — 3
+	Compensate  *WorkflowAction       `json:"compensate,omitempty"`
+}
+
+// WorkflowAction — компенсирующее действие шага для политики compensate.
+// Inputs могут ссылаться на сам шаг и на шаги, от которых он зависит.
+type WorkflowAction struct {
+	Type    JobType           `json:"type" validate:"required"`
+	Payload map[string]any    `json:"payload,omitempty"`
+	Inputs  map[string]string `json:"inputs,omitempty"`
+}
@@ -0,0 +1,87 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/joblog"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/progress"
+)
+
+// executor возвращает Executor, выполняющий задачи jobType в процессе плагина.
+// Прогресс из потока событий пересылается в progress.Reporter задачи.
+func (p *plugin) executor(jobType models.JobType) jobregistry.Executor {
+	return func(ctx context.Context, payload string) (any, error) {
+		if !p.isReady() {
+			return nil, models.Retryable(fmt.Errorf("%s: %w", p.name, ErrUnavailable))
+		}
+
+		req := &pb.PluginExecuteRequest{
+			JobType: string(jobType),
+			Payload: payload,
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			req.DeadlineUnixMs = deadline.UnixMilli()
+		}
+
+		logger := joblog.FromContext(ctx).With(slog.String("plugin", p.name))
+		logger.Debug("Executing job in plugin")
+
+		stream, err := p.client.Execute(ctx, req)
+		if err != nil {
+			return nil, models.Retryable(fmt.Errorf("plugin %s: %w", p.name, err))
+		}
+
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				switch {
+				case ctx.Err() != nil:
+					return nil, ctx.Err()
+				case errors.Is(err, io.EOF):
+					return nil, fmt.Errorf("plugin %s closed stream without result", p.name)
+				default:
+					logger.Warn("Plugin stream failed", slog.String("error", err.Error()))
+					return nil, models.Retryable(fmt.Errorf("plugin %s: %w", p.name, err))
+				}
+			}
+
+			if pr := ev.GetProgress(); pr != nil {
+				progress.Report(ctx, progress.Update{
+					Percent: pr.GetPercent(),
+					Stage:   pr.GetStage(),
+					ETA:     time.Duration(pr.GetEtaSeconds()) * time.Second,
+				})
+				continue
+			}
+
+			if res := ev.GetResult(); res != nil {
+				if res.GetError() == "" {
+					return decodeOutput(res.GetOutput()), nil
+				}
+				err := errors.New(res.GetError())
+				if res.GetRetryable() {
+					err = models.Retryable(err)
+				}
+				return nil, err
+			}
+		}
+	}
+}
+
+// decodeOutput возвращает JSON результата плагина как есть. Вывод, не
+// являющийся JSON (старые плагины), передается строкой.
+func decodeOutput(output string) any {
+	if json.Valid([]byte(output)) {
+		return json.RawMessage(output)
+	}
+	return output
+}
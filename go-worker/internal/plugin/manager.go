@@ -0,0 +1,270 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// Manager находит плагины, запускает их, регистрирует их типы задач
+// в jobregistry и следит за ними до остановки воркера.
+type Manager struct {
+	cfg *config.Config
+
+	mu      sync.Mutex
+	plugins []*plugin // Зарегистрированные
+	pending []*plugin // Не запустились при старте, запускаются повторно (см. Retry)
+
+	socketDir string
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+func NewManager(cfg *config.Config) *Manager {
+	return &Manager{cfg: cfg}
+}
+
+// Start запускает все плагины и регистрирует их executor'ы.
+// Должен вызываться до jobregistry.CreateExecutors. Плагин, который не удалось
+// запустить, откладывается с ошибкой в логе — остальные типы задач продолжают работать,
+// а повторные попытки запускает Retry.
+func (m *Manager) Start(ctx context.Context) error {
+	plugins, err := m.discover()
+	if err != nil {
+		return err
+	}
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	m.ctx, m.cancel = context.WithCancel(ctx)
+
+	for _, p := range plugins {
+		err := m.startPlugin(p)
+		switch {
+		case err == nil:
+		case errors.Is(err, errRegister):
+			// Конфликт типов задач не исправится повторным запуском
+			slog.Error("Failed to register plugin", slog.String("plugin", p.name), "error", err)
+			p.stop()
+		default:
+			slog.Error("Failed to start plugin, will retry", slog.String("plugin", p.name), "error", err)
+			p.kill()
+			m.mu.Lock()
+			m.pending = append(m.pending, p)
+			m.mu.Unlock()
+		}
+	}
+
+	return nil
+}
+
+// Retry повторяет запуск плагинов, не запустившихся в Start, с экспоненциальной
+// задержкой до успеха или остановки. onRegister вызывается с типами задач
+// плагина, зарегистрированными в jobregistry после CreateExecutors.
+func (m *Manager) Retry(onRegister func([]models.JobType)) {
+	m.mu.Lock()
+	pending := m.pending
+	m.mu.Unlock()
+
+	for _, p := range pending {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.retryPlugin(p, onRegister)
+		}()
+	}
+}
+
+// Stop останавливает наблюдение и процессы плагинов и удаляет их типы задач из jobregistry.
+func (m *Manager) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, p := range m.plugins {
+		p.stop()
+		for _, jt := range p.jobTypes {
+			jobregistry.Unregister(jt)
+		}
+	}
+	for _, p := range m.pending {
+		p.stop()
+	}
+	if m.socketDir != "" {
+		_ = os.RemoveAll(m.socketDir)
+	}
+}
+
+var errRegister = errors.New("register")
+
+// startPlugin запускает плагин, регистрирует его типы задач и начинает наблюдение.
+func (m *Manager) startPlugin(p *plugin) error {
+	if err := p.launch(m.ctx); err != nil {
+		return err
+	}
+	if err := m.register(m.ctx, p); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.plugins = append(m.plugins, p)
+	m.pending = slices.DeleteFunc(m.pending, func(other *plugin) bool { return other == p })
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		p.supervise(m.ctx)
+	}()
+	return nil
+}
+
+func (m *Manager) retryPlugin(p *plugin, onRegister func([]models.JobType)) {
+	backoff := p.settings.restartBackoff
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		err := m.startPlugin(p)
+		switch {
+		case err == nil:
+			slog.Info("Plugin started after retry", slog.String("plugin", p.name))
+			if onRegister != nil {
+				onRegister(p.jobTypes)
+			}
+			return
+		case errors.Is(err, errRegister):
+			slog.Error("Failed to register plugin", slog.String("plugin", p.name), "error", err)
+			p.kill()
+			return
+		}
+
+		slog.Warn("Failed to start plugin", slog.String("plugin", p.name), "error", err)
+		p.kill()
+		backoff = min(backoff*2, maxRestartBackoff)
+	}
+}
+
+// Status — состояние запущенного плагина.
+type Status struct {
+	Name     string
+	JobTypes []models.JobType
+	Ready    bool // Процесс запущен и проходит health-check
+}
+
+// Status возвращает состояние плагинов; еще не запустившиеся — без типов задач и не готовы.
+func (m *Manager) Status() []Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := make([]Status, 0, len(m.plugins)+len(m.pending))
+	for _, p := range m.plugins {
+		res = append(res, Status{Name: p.name, JobTypes: p.jobTypes, Ready: p.isReady()})
+	}
+	for _, p := range m.pending {
+		res = append(res, Status{Name: p.name})
+	}
+	return res
+}
+
+func (m *Manager) register(ctx context.Context, p *plugin) error {
+	desc, err := p.describe(ctx)
+	if err != nil {
+		return fmt.Errorf("describe: %w", err)
+	}
+
+	for _, name := range desc.GetJobTypes() {
+		jobType := models.JobType(name)
+		exec := p.executor(jobType)
+		err := jobregistry.RegisterExternal(jobType, func(*config.Config) jobregistry.Executor {
+			return exec
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %w", errRegister, err)
+		}
+		p.jobTypes = append(p.jobTypes, jobType)
+	}
+
+	slog.Info("Plugin registered",
+		slog.String("plugin", p.name),
+		slog.String("version", desc.GetVersion()),
+		slog.Any("job_types", desc.GetJobTypes()),
+	)
+	return nil
+}
+
+// discover собирает плагины из PLUGINS_DIR (исполняемые файлы)
+// и из PLUGINS (уже запущенные плагины вида name=address).
+func (m *Manager) discover() ([]*plugin, error) {
+	s := settings{
+		startTimeout:      m.cfg.PluginStartTimeout,
+		healthInterval:    m.cfg.PluginHealthInterval,
+		healthTimeout:     m.cfg.PluginHealthTimeout,
+		maxHealthFailures: m.cfg.PluginMaxHealthFailures,
+		restartBackoff:    m.cfg.PluginRestartBackoff,
+	}
+
+	var plugins []*plugin
+
+	if m.cfg.PluginsDir != "" {
+		entries, err := os.ReadDir(m.cfg.PluginsDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read PLUGINS_DIR: %w", err)
+		}
+
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+				continue
+			}
+
+			if m.socketDir == "" {
+				if m.socketDir, err = os.MkdirTemp("", "job-plugins"); err != nil {
+					return nil, fmt.Errorf("failed to create plugin socket dir: %w", err)
+				}
+			}
+
+			name := e.Name()
+			addr := "unix://" + filepath.Join(m.socketDir, name+".sock")
+			p, err := newPlugin(name, filepath.Join(m.cfg.PluginsDir, name), addr, s)
+			if err != nil {
+				return nil, err
+			}
+			plugins = append(plugins, p)
+		}
+	}
+
+	for _, item := range m.cfg.Plugins {
+		name, addr, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || name == "" || addr == "" {
+			return nil, fmt.Errorf("invalid PLUGINS entry %q, expected name=address", item)
+		}
+		p, err := newPlugin(name, "", addr, s)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, p)
+	}
+
+	return plugins, nil
+}
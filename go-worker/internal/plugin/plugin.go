@@ -0,0 +1,252 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// AddrEnv — переменная окружения, через которую плагин получает адрес для прослушивания.
+	AddrEnv = "JOB_PLUGIN_ADDR"
+
+	startPollInterval = 100 * time.Millisecond
+	stopGracePeriod   = 5 * time.Second
+	maxRestartBackoff = 30 * time.Second
+)
+
+// ErrUnavailable — плагин не запущен или не проходит health-check.
+var ErrUnavailable = errors.New("plugin unavailable")
+
+type settings struct {
+	startTimeout      time.Duration
+	healthInterval    time.Duration
+	healthTimeout     time.Duration
+	maxHealthFailures int
+	restartBackoff    time.Duration
+}
+
+// plugin — один внешний executor. Если path задан, воркер сам запускает
+// процесс и перезапускает его при падении; иначе подключается к готовому адресу.
+type plugin struct {
+	name     string
+	path     string
+	addr     string
+	settings settings
+
+	conn     *grpc.ClientConn
+	client   pb.ExecutorPluginClient
+	jobTypes []models.JobType // Заполняется при регистрации
+
+	mu     sync.RWMutex
+	ready  bool
+	cmd    *exec.Cmd
+	exited chan struct{}
+}
+
+func newPlugin(name, path, addr string, s settings) (*plugin, error) {
+	// Соединение переживает перезапуски процесса: gRPC сам переподключается к адресу
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", name, err)
+	}
+
+	return &plugin{
+		name:     name,
+		path:     path,
+		addr:     addr,
+		settings: s,
+		conn:     conn,
+		client:   pb.NewExecutorPluginClient(conn),
+	}, nil
+}
+
+// launch запускает процесс плагина (если он управляется воркером)
+// и ждет первого успешного health-check не дольше startTimeout.
+func (p *plugin) launch(ctx context.Context) error {
+	var exited chan struct{}
+	if p.path != "" {
+		cmd := exec.Command(p.path) //nolint:noctx // жизненным циклом процесса управляет supervise
+		cmd.Env = append(os.Environ(), AddrEnv+"="+p.addr)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start plugin %s: %w", p.name, err)
+		}
+
+		exited = make(chan struct{})
+		go func() {
+			_ = cmd.Wait()
+			close(exited)
+		}()
+
+		p.mu.Lock()
+		p.cmd, p.exited = cmd, exited
+		p.mu.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.settings.startTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(startPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.health(ctx); err == nil {
+			p.setReady(true)
+			return nil
+		}
+
+		select {
+		case <-exited:
+			return fmt.Errorf("plugin %s exited during startup", p.name)
+		case <-ctx.Done():
+			p.kill()
+			return fmt.Errorf("plugin %s did not become healthy within %s", p.name, p.settings.startTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// supervise следит за плагином: перезапускает упавший процесс
+// и убивает зависший (не отвечающий на health-check).
+func (p *plugin) supervise(ctx context.Context) {
+	ticker := time.NewTicker(p.settings.healthInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-p.exitedChan():
+			p.setReady(false)
+			slog.Warn("Plugin process exited, restarting", slog.String("plugin", p.name))
+			p.restart(ctx)
+			failures = 0
+
+		case <-ticker.C:
+			hctx, cancel := context.WithTimeout(ctx, p.settings.healthTimeout)
+			err := p.health(hctx)
+			cancel()
+
+			if err == nil {
+				failures = 0
+				p.setReady(true)
+				continue
+			}
+
+			failures++
+			slog.Warn("Plugin health check failed",
+				slog.String("plugin", p.name),
+				slog.Int("failures", failures),
+				slog.String("error", err.Error()),
+			)
+			if failures >= p.settings.maxHealthFailures {
+				p.setReady(false)
+				// Зависший процесс убиваем, перезапуск произойдет через exitedChan
+				p.kill()
+			}
+		}
+	}
+}
+
+// restart перезапускает процесс с экспоненциальной задержкой, пока не получится.
+func (p *plugin) restart(ctx context.Context) {
+	backoff := p.settings.restartBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		err := p.launch(ctx)
+		if err == nil {
+			slog.Info("Plugin restarted", slog.String("plugin", p.name))
+			return
+		}
+
+		slog.Error("Failed to restart plugin", slog.String("plugin", p.name), "error", err)
+		backoff = min(backoff*2, maxRestartBackoff)
+	}
+}
+
+func (p *plugin) health(ctx context.Context) error {
+	resp, err := p.client.Health(ctx, &pb.PluginHealthRequest{})
+	if err != nil {
+		return err
+	}
+	if !resp.GetServing() {
+		return fmt.Errorf("plugin %s is not serving", p.name)
+	}
+	return nil
+}
+
+func (p *plugin) describe(ctx context.Context) (*pb.PluginDescribeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.settings.startTimeout)
+	defer cancel()
+	return p.client.Describe(ctx, &pb.PluginDescribeRequest{})
+}
+
+// stop мягко останавливает процесс и закрывает соединение.
+func (p *plugin) stop() {
+	p.setReady(false)
+
+	p.mu.RLock()
+	cmd, exited := p.cmd, p.exited
+	p.mu.RUnlock()
+
+	if cmd != nil && cmd.Process != nil {
+		_ = cmd.Process.Signal(os.Interrupt)
+		select {
+		case <-exited:
+		case <-time.After(stopGracePeriod):
+			_ = cmd.Process.Kill()
+		}
+	}
+
+	if err := p.conn.Close(); err != nil {
+		slog.Warn("Failed to close plugin connection", slog.String("plugin", p.name), "error", err)
+	}
+}
+
+func (p *plugin) kill() {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.cmd != nil && p.cmd.Process != nil {
+		_ = p.cmd.Process.Kill()
+	}
+}
+
+// exitedChan возвращает nil для плагинов, которыми воркер не управляет:
+// nil-канал никогда не срабатывает в select.
+func (p *plugin) exitedChan() <-chan struct{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.exited
+}
+
+func (p *plugin) setReady(ready bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ready = ready
+}
+
+func (p *plugin) isReady() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.ready
+}
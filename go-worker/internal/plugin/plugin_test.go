@@ -0,0 +1,130 @@
+package plugin_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/plugin"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/progress"
+)
+
+func TestManagerRegistersExternalPlugin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	addr := "unix://" + filepath.Join(t.TempDir(), "echo.sock")
+	t.Setenv(plugin.AddrEnv, addr)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- plugin.Serve(ctx, plugin.Info{Name: "echo", JobTypes: []string{"TEST_ECHO"}},
+			func(ctx context.Context, _, payload string) (any, error) {
+				progress.Report(ctx, progress.Update{Percent: 50, Stage: "echo"})
+				return map[string]string{"echo": strings.ToUpper(payload)}, nil
+			})
+	}()
+
+	cfg := &config.Config{
+		Plugins:                 []string{"echo=" + addr},
+		PluginStartTimeout:      2 * time.Second,
+		PluginHealthInterval:    time.Second,
+		PluginHealthTimeout:     time.Second,
+		PluginMaxHealthFailures: 3,
+		PluginRestartBackoff:    10 * time.Millisecond,
+	}
+	m := plugin.NewManager(cfg)
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	t.Cleanup(func() {
+		m.Stop()
+		if slices.Contains(jobregistry.Names(), "TEST_ECHO") {
+			t.Error("plugin job type must be unregistered on stop")
+		}
+	})
+
+	exec, ok := jobregistry.CreateExecutors(cfg, nil)["TEST_ECHO"]
+	if !ok {
+		t.Fatal("plugin job type was not registered")
+	}
+
+	var reported []progress.Update
+	jobCtx := progress.WithReporter(ctx, reporterFunc(func(u progress.Update) {
+		reported = append(reported, u)
+	}))
+
+	out, err := exec(jobCtx, "hello")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if raw, ok := out.(json.RawMessage); !ok || string(raw) != `{"echo":"HELLO"}` {
+		t.Errorf("expected JSON result from plugin, got %#v", out)
+	}
+	if len(reported) != 1 || reported[0].Stage != "echo" {
+		t.Errorf("expected progress from plugin, got %+v", reported)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("serve: %v", err)
+	}
+}
+
+type reporterFunc func(progress.Update)
+
+func (f reporterFunc) Report(u progress.Update) { f(u) }
+
+func TestManagerRetriesPluginThatFailedToStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	addr := "unix://" + filepath.Join(t.TempDir(), "late.sock")
+	t.Setenv(plugin.AddrEnv, addr)
+
+	cfg := &config.Config{
+		Plugins:                 []string{"late=" + addr},
+		PluginStartTimeout:      100 * time.Millisecond,
+		PluginHealthInterval:    time.Second,
+		PluginHealthTimeout:     time.Second,
+		PluginMaxHealthFailures: 3,
+		PluginRestartBackoff:    10 * time.Millisecond,
+	}
+	m := plugin.NewManager(cfg)
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer m.Stop()
+
+	if slices.Contains(jobregistry.Names(), "TEST_LATE") {
+		t.Fatal("plugin must not be registered before it is started")
+	}
+
+	registered := make(chan []models.JobType, 1)
+	m.Retry(func(jobTypes []models.JobType) { registered <- jobTypes })
+
+	// Плагин поднимается позже воркера, например sidecar-контейнер
+	go func() {
+		_ = plugin.Serve(ctx, plugin.Info{Name: "late", JobTypes: []string{"TEST_LATE"}},
+			func(context.Context, string, string) (any, error) { return nil, nil })
+	}()
+
+	select {
+	case jobTypes := <-registered:
+		if !slices.Equal(jobTypes, []models.JobType{"TEST_LATE"}) {
+			t.Fatalf("unexpected job types: %v", jobTypes)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("plugin was not started after retry")
+	}
+	if !slices.Contains(jobregistry.Names(), "TEST_LATE") {
+		t.Fatal("plugin job type must be registered after retry")
+	}
+}
@@ -0,0 +1,142 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/progress"
+	"google.golang.org/grpc"
+)
+
+// Info описывает плагин для Describe.
+type Info struct {
+	Name     string
+	Version  string
+	JobTypes []string
+}
+
+// ExecuteFunc выполняет задачу внутри процесса плагина. Прогресс публикуется
+// обычным progress.Report(ctx, ...), ошибки models.Retryable помечаются как временные.
+// Результат кодируется в JSON и передается воркеру как есть.
+type ExecuteFunc func(ctx context.Context, jobType, payload string) (any, error)
+
+// Serve запускает gRPC сервер плагина на адресе из JOB_PLUGIN_ADDR
+// и обслуживает запросы до отмены ctx.
+func Serve(ctx context.Context, info Info, execute ExecuteFunc) error {
+	addr := os.Getenv(AddrEnv)
+	if addr == "" {
+		return fmt.Errorf("%s is not set", AddrEnv)
+	}
+
+	network, address := "tcp", addr
+	if path, ok := strings.CutPrefix(addr, "unix://"); ok {
+		network, address = "unix", path
+	}
+
+	lis, err := (&net.ListenConfig{}).Listen(ctx, network, address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterExecutorPluginServer(srv, &server{info: info, execute: execute})
+
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+type server struct {
+	pb.UnimplementedExecutorPluginServer
+
+	info    Info
+	execute ExecuteFunc
+}
+
+func (s *server) Describe(context.Context, *pb.PluginDescribeRequest) (*pb.PluginDescribeResponse, error) {
+	return &pb.PluginDescribeResponse{
+		Name:     s.info.Name,
+		Version:  s.info.Version,
+		JobTypes: s.info.JobTypes,
+	}, nil
+}
+
+func (s *server) Health(context.Context, *pb.PluginHealthRequest) (*pb.PluginHealthResponse, error) {
+	return &pb.PluginHealthResponse{Serving: true}, nil
+}
+
+func (s *server) Execute(
+	req *pb.PluginExecuteRequest,
+	stream grpc.ServerStreamingServer[pb.PluginExecuteEvent],
+) error {
+	reporter := &streamReporter{stream: stream}
+	ctx := progress.WithReporter(stream.Context(), reporter)
+
+	output, err := s.execute(ctx, req.GetJobType(), req.GetPayload())
+
+	res := &pb.PluginResult{}
+	if err == nil {
+		var data []byte
+		if data, err = json.Marshal(output); err != nil {
+			err = fmt.Errorf("failed to encode result: %w", err)
+		}
+		res.Output = string(data)
+	}
+	if err != nil {
+		res.Output = ""
+		res.Error = err.Error()
+		res.Retryable = models.IsRetryable(err)
+	}
+	return reporter.finish(&pb.PluginExecuteEvent{
+		Event: &pb.PluginExecuteEvent_Result{Result: res},
+	})
+}
+
+// streamReporter отправляет прогресс в поток Execute. Executor может сообщать
+// прогресс из своих горутин, а Send потока нельзя вызывать конкурентно,
+// поэтому все отправки, включая итоговую, идут под mu.
+type streamReporter struct {
+	stream grpc.ServerStreamingServer[pb.PluginExecuteEvent]
+
+	mu       sync.Mutex
+	finished bool // Результат отправлен, поток закрывается
+}
+
+func (r *streamReporter) Report(u progress.Update) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.finished {
+		return
+	}
+	_ = r.stream.Send(&pb.PluginExecuteEvent{
+		Event: &pb.PluginExecuteEvent_Progress{Progress: &pb.PluginProgress{
+			Percent:    u.Percent,
+			Stage:      u.Stage,
+			EtaSeconds: int64(u.ETA.Seconds()),
+		}},
+	})
+}
+
+// finish отправляет итоговое событие; прогресс после него отбрасывается.
+func (r *streamReporter) finish(event *pb.PluginExecuteEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.finished = true
+	return r.stream.Send(event)
+}
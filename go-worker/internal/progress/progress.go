@@ -0,0 +1,86 @@
+package progress
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Update — снимок прогресса выполнения задачи.
+type Update struct {
+	Percent float64       // 0..100
+	Stage   string        // Название текущего этапа
+	ETA     time.Duration // Оценка оставшегося времени, 0 — неизвестно
+}
+
+// Reporter принимает обновления прогресса от executor'а.
+type Reporter interface {
+	Report(u Update)
+}
+
+type ctxKey struct{}
+
+// WithReporter кладет Reporter в контекст задачи.
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, ctxKey{}, r)
+}
+
+// Report публикует прогресс через Reporter из контекста.
+// Если Reporter не установлен, вызов ничего не делает.
+func Report(ctx context.Context, u Update) {
+	r, ok := ctx.Value(ctxKey{}).(Reporter)
+	if !ok || r == nil {
+		return
+	}
+	r.Report(u)
+}
+
+// throttled пропускает не больше одного обновления за interval.
+// Смена этапа и достижение 100% пропускаются всегда.
+type throttled struct {
+	interval time.Duration
+	emit     func(Update)
+
+	mu        sync.Mutex
+	lastEmit  time.Time
+	lastStage string
+}
+
+// NewThrottled создает Reporter, который ограничивает частоту вызовов emit.
+func NewThrottled(interval time.Duration, emit func(Update)) Reporter {
+	return &throttled{
+		interval: interval,
+		emit:     emit,
+	}
+}
+
+func (t *throttled) Report(u Update) {
+	u.Percent = clampPercent(u.Percent)
+
+	t.mu.Lock()
+	now := time.Now()
+	pass := t.lastEmit.IsZero() ||
+		now.Sub(t.lastEmit) >= t.interval ||
+		u.Stage != t.lastStage ||
+		u.Percent >= 100
+	if pass {
+		t.lastEmit = now
+		t.lastStage = u.Stage
+	}
+	t.mu.Unlock()
+
+	if pass {
+		t.emit(u)
+	}
+}
+
+func clampPercent(p float64) float64 {
+	switch {
+	case p < 0:
+		return 0
+	case p > 100:
+		return 100
+	default:
+		return p
+	}
+}
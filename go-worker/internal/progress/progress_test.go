@@ -0,0 +1,36 @@
+package progress_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/progress"
+)
+
+func TestReportWithoutReporterIsNoop(t *testing.T) {
+	progress.Report(context.Background(), progress.Update{Percent: 50})
+}
+
+func TestThrottledReporter(t *testing.T) {
+	var got []progress.Update
+	r := progress.NewThrottled(time.Hour, func(u progress.Update) {
+		got = append(got, u)
+	})
+	ctx := progress.WithReporter(context.Background(), r)
+
+	progress.Report(ctx, progress.Update{Percent: 10, Stage: "download"})
+	progress.Report(ctx, progress.Update{Percent: 20, Stage: "download"}) // отброшено
+	progress.Report(ctx, progress.Update{Percent: 30, Stage: "resize"})   // новый этап
+	progress.Report(ctx, progress.Update{Percent: 150, Stage: "resize"})  // 100% всегда
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 updates, got %d: %+v", len(got), got)
+	}
+	if got[1].Stage != "resize" {
+		t.Errorf("expected stage change to pass, got %q", got[1].Stage)
+	}
+	if got[2].Percent != 100 {
+		t.Errorf("expected percent clamped to 100, got %v", got[2].Percent)
+	}
+}
@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleHostBucketsEvicted(t *testing.T) {
+	l := &Limiter{}
+	if err := l.Reload(nil, []string{"*.example.com=0.001:1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	idle := l.hostBucket("idle.example.com")
+	busy := l.hostBucket("busy.example.com")
+	if !busy.limiter.Allow() {
+		t.Fatal("first token should be available")
+	}
+
+	now := time.Now().Add(hostIdleTTL)
+	idle.lastUsed = now.Add(-hostIdleTTL)
+	busy.lastUsed = now.Add(-hostIdleTTL)
+
+	l.mu.Lock()
+	l.evictIdleHosts(now)
+	_, idleKept := l.hosts["idle.example.com"]
+	_, busyKept := l.hosts["busy.example.com"]
+	l.mu.Unlock()
+
+	if idleKept {
+		t.Error("expected idle full bucket to be evicted")
+	}
+	if !busyKept {
+		t.Error("expected drained bucket to be kept until it refills")
+	}
+}
@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	waitSeconds = promauto.With(metrics.Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "ratelimit",
+		Name:      "wait_seconds",
+		Help:      "Time spent waiting for a rate limit token.",
+		Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 5, 10, 30},
+	}, []string{"scope"})
+
+	rejectedTotal = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "ratelimit",
+		Name:      "rejected_total",
+		Help:      "Waits that could not get a token before the job deadline.",
+	}, []string{"scope"})
+
+	tokensDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "ratelimit", "tokens"),
+		"Tokens currently available in a bucket.",
+		[]string{"scope", "key"}, nil,
+	)
+)
+
+// Describe реализует prometheus.Collector.
+func (l *Limiter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tokensDesc
+}
+
+// Collect реализует prometheus.Collector: отдает текущее число токенов в каждом bucket'е.
+func (l *Limiter) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range l.State() {
+		ch <- prometheus.MustNewConstMetric(tokensDesc, prometheus.GaugeValue, s.Tokens, s.Scope, s.Key)
+	}
+}
@@ -0,0 +1,257 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"golang.org/x/time/rate"
+)
+
+const (
+	ScopeJobType = "job_type"
+	ScopeHost    = "host"
+)
+
+// hostIdleTTL — через сколько без обращений bucket хоста удаляется.
+// Удаляются только полные bucket'ы, поэтому лимит от этого не ослабевает,
+// а набор хостов (и метрик по ним) не растет бесконечно.
+const hostIdleTTL = 10 * time.Minute
+
+// Rule — ограничение вида "pattern=rate[:burst]", rate — токенов в секунду.
+type Rule struct {
+	Pattern string
+	Rate    float64
+	Burst   int
+}
+
+// BucketState — текущее состояние одного token bucket'а.
+type BucketState struct {
+	Scope  string  `json:"scope"`
+	Key    string  `json:"key"`
+	Rule   string  `json:"rule"`
+	Rate   float64 `json:"rate"`
+	Burst  int     `json:"burst"`
+	Tokens float64 `json:"tokens"`
+}
+
+type bucket struct {
+	rule     Rule
+	limiter  *rate.Limiter
+	lastUsed time.Time // Только для bucket'ов хостов, под Limiter.mu
+}
+
+// Limiter — набор token bucket'ов по типам задач и по хостам назначения.
+type Limiter struct {
+	mu        sync.Mutex
+	jobTypes  map[models.JobType]*bucket
+	hostRules []Rule
+	hosts     map[string]*bucket
+	lastSweep time.Time
+}
+
+func New(cfg *config.Config) (*Limiter, error) {
+	l := &Limiter{}
+	if err := l.Reload(cfg.RateLimitJobTypes, cfg.RateLimitHosts); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Reload заменяет правила лимитов. Если хотя бы одно правило некорректно,
+// текущие правила не меняются. Bucket'ы хостов создаются заново по новым правилам.
+func (l *Limiter) Reload(jobTypes, hosts []string) error {
+	jobTypeRules, err := ParseRules(jobTypes)
+	if err != nil {
+		return fmt.Errorf("RATE_LIMIT_JOB_TYPES: %w", err)
+	}
+	hostRules, err := ParseRules(hosts)
+	if err != nil {
+		return fmt.Errorf("RATE_LIMIT_HOSTS: %w", err)
+	}
+
+	buckets := make(map[models.JobType]*bucket, len(jobTypeRules))
+	for _, r := range jobTypeRules {
+		buckets[models.JobType(r.Pattern)] = newBucket(r)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.jobTypes = buckets
+	l.hostRules = hostRules
+	l.hosts = make(map[string]*bucket)
+
+	return nil
+}
+
+// ParseRules разбирает список правил вида "pattern=rate[:burst]".
+// Если burst не указан, он равен округленному вверх rate.
+func ParseRules(items []string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		pattern, spec, ok := strings.Cut(item, "=")
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("invalid rule %q, expected pattern=rate[:burst]", item)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+
+		rateStr, burstStr, hasBurst := strings.Cut(spec, ":")
+		r, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil || r <= 0 {
+			return nil, fmt.Errorf("invalid rate in rule %q", item)
+		}
+
+		burst := max(int(r+0.999), 1)
+		if hasBurst {
+			burst, err = strconv.Atoi(burstStr)
+			if err != nil || burst <= 0 {
+				return nil, fmt.Errorf("invalid burst in rule %q", item)
+			}
+		}
+
+		rules = append(rules, Rule{Pattern: pattern, Rate: r, Burst: burst})
+	}
+	return rules, nil
+}
+
+// WaitJobType ждет токен для типа задачи. Ожидание ограничено дедлайном ctx:
+// если токен не успеет появиться до дедлайна, возвращается ошибка.
+func (l *Limiter) WaitJobType(ctx context.Context, jobType models.JobType) error {
+	l.mu.Lock()
+	b, ok := l.jobTypes[jobType]
+	l.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return wait(ctx, b, ScopeJobType, string(jobType))
+}
+
+// WaitHost ждет токен для хоста назначения.
+func (l *Limiter) WaitHost(ctx context.Context, host string) error {
+	b := l.hostBucket(host)
+	if b == nil {
+		return nil
+	}
+	return wait(ctx, b, ScopeHost, host)
+}
+
+// State возвращает снимок всех bucket'ов, отсортированный по scope и ключу.
+func (l *Limiter) State() []BucketState {
+	l.mu.Lock()
+	l.evictIdleHosts(time.Now())
+	res := make([]BucketState, 0, len(l.jobTypes)+len(l.hosts))
+	for jt, b := range l.jobTypes {
+		res = append(res, b.state(ScopeJobType, string(jt)))
+	}
+	for host, b := range l.hosts {
+		res = append(res, b.state(ScopeHost, host))
+	}
+	l.mu.Unlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Scope != res[j].Scope {
+			return res[i].Scope < res[j].Scope
+		}
+		return res[i].Key < res[j].Key
+	})
+	return res
+}
+
+// hostBucket возвращает bucket хоста, создавая его по первому подходящему правилу.
+func (l *Limiter) hostBucket(host string) *bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.evictIdleHosts(now)
+
+	if b, ok := l.hosts[host]; ok {
+		b.lastUsed = now
+		return b
+	}
+	for _, r := range l.hostRules {
+		if ok, _ := path.Match(r.Pattern, host); ok {
+			b := newBucket(r)
+			b.lastUsed = now
+			l.hosts[host] = b
+			return b
+		}
+	}
+	return nil
+}
+
+// evictIdleHosts не чаще раза в hostIdleTTL удаляет bucket'ы хостов, к которым
+// не обращались дольше hostIdleTTL и которые успели полностью наполниться.
+// Вызывается под l.mu.
+func (l *Limiter) evictIdleHosts(now time.Time) {
+	if now.Sub(l.lastSweep) < hostIdleTTL {
+		return
+	}
+	l.lastSweep = now
+
+	for host, b := range l.hosts {
+		if now.Sub(b.lastUsed) >= hostIdleTTL && b.limiter.TokensAt(now) >= float64(b.rule.Burst) {
+			delete(l.hosts, host)
+		}
+	}
+}
+
+func newBucket(r Rule) *bucket {
+	return &bucket{rule: r, limiter: rate.NewLimiter(rate.Limit(r.Rate), r.Burst)}
+}
+
+func (b *bucket) state(scope, key string) BucketState {
+	return BucketState{
+		Scope:  scope,
+		Key:    key,
+		Rule:   b.rule.Pattern,
+		Rate:   b.rule.Rate,
+		Burst:  b.rule.Burst,
+		Tokens: b.limiter.Tokens(),
+	}
+}
+
+func wait(ctx context.Context, b *bucket, scope, key string) error {
+	start := time.Now()
+	err := b.limiter.Wait(ctx)
+	waitSeconds.WithLabelValues(scope).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		rejectedTotal.WithLabelValues(scope).Inc()
+		return fmt.Errorf("rate limit %s %q: %w", scope, key, err)
+	}
+	return nil
+}
+
+type ctxKey struct{}
+
+// WithLimiter кладет Limiter в контекст задачи, чтобы executor'ы могли
+// ограничивать обращения к внешним хостам.
+func WithLimiter(ctx context.Context, l *Limiter) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// WaitHost ждет токен для хоста через Limiter из контекста.
+// Если Limiter не установлен, ограничения нет.
+func WaitHost(ctx context.Context, host string) error {
+	l, ok := ctx.Value(ctxKey{}).(*Limiter)
+	if !ok || l == nil {
+		return nil
+	}
+	return l.WaitHost(ctx, host)
+}
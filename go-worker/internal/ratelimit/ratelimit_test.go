@@ -0,0 +1,69 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/ratelimit"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ratelimit.ParseRules([]string{"*.example.com=5:10", " api.github.com=0.5 "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].Rate != 5 || rules[0].Burst != 10 {
+		t.Errorf("unexpected first rule: %+v", rules[0])
+	}
+	if rules[1].Burst != 1 {
+		t.Errorf("expected default burst 1, got %d", rules[1].Burst)
+	}
+
+	for _, bad := range []string{"noequals", "host=abc", "host=1:0", "[=1"} {
+		if _, err := ratelimit.ParseRules([]string{bad}); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestWaitRespectsDeadline(t *testing.T) {
+	l, err := ratelimit.New(&config.Config{
+		Reloadable: config.Reloadable{
+			RateLimitJobTypes: []string{"HTTP_GET=0.1:1"},
+			RateLimitHosts:    []string{"*.example.com=0.1:1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := l.WaitJobType(ctx, models.JobTypeHttpGet); err != nil {
+		t.Fatalf("first token should be available: %v", err)
+	}
+	if err := l.WaitJobType(ctx, models.JobTypeHttpGet); err == nil {
+		t.Fatal("expected wait to fail: next token is due after the deadline")
+	}
+	if err := l.WaitJobType(ctx, models.JobTypeSleep); err != nil {
+		t.Fatalf("unlimited job type must not wait: %v", err)
+	}
+
+	ctx = ratelimit.WithLimiter(ctx, l)
+	if err := ratelimit.WaitHost(ctx, "api.example.com"); err != nil {
+		t.Fatalf("first host token should be available: %v", err)
+	}
+	if err := ratelimit.WaitHost(ctx, "api.example.com"); err == nil {
+		t.Fatal("expected host wait to fail")
+	}
+	if err := ratelimit.WaitHost(ctx, "other.org"); err != nil {
+		t.Fatalf("unmatched host must not wait: %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+// Package reload перечитывает config.Reloadable по SIGHUP или при изменении
+// файла CONFIG_RELOAD_FILE и применяет изменения без перезапуска воркера.
+package reload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/ratelimit"
+)
+
+// Applier применяет новую конфигурацию к одному компоненту.
+// Вызывается только для уже провалидированной конфигурации.
+type Applier func(cfg config.Reloadable) error
+
+type Reloader struct {
+	path     string
+	interval time.Duration
+	appliers []Applier
+	jobTypes map[string]config.JobTypeConfig // Не перечитываются, но зависят от MAX_JOB_TIMEOUT
+
+	mu      sync.Mutex
+	current config.Reloadable
+	modTime time.Time
+
+	wg  sync.WaitGroup
+	ctx context.Context
+}
+
+func NewReloader(cfg *config.Config, ctx context.Context) *Reloader {
+	return &Reloader{
+		path:     cfg.ReloadFile,
+		interval: cfg.ReloadInterval,
+		current:  cfg.Reloadable,
+		jobTypes: cfg.JobTypes,
+		ctx:      ctx,
+	}
+}
+
+// OnReload добавляет Applier. Вызывается до Start.
+func (r *Reloader) OnReload(apply Applier) {
+	r.appliers = append(r.appliers, apply)
+}
+
+func (r *Reloader) Start() {
+	// Файл, измененный до старта, применяется сразу
+	if r.path != "" {
+		if err := r.Reload(); err != nil {
+			slog.Error("Config reload failed", slog.String("error", err.Error()))
+		}
+	}
+
+	r.wg.Add(1)
+	go r.run()
+}
+
+func (r *Reloader) Stop() {
+	r.wg.Wait()
+}
+
+func (r *Reloader) run() {
+	defer r.wg.Done()
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	// Без файла перечитываем только по SIGHUP
+	var tick <-chan time.Time
+	if r.path != "" && r.interval > 0 {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-sighup:
+			slog.Info("SIGHUP received, reloading config")
+			if err := r.Reload(); err != nil {
+				slog.Error("Config reload failed", slog.String("error", err.Error()))
+			}
+		case <-tick:
+			if !r.fileChanged() {
+				continue
+			}
+			slog.Info("Config file changed, reloading", slog.String("path", r.path))
+			if err := r.Reload(); err != nil {
+				slog.Error("Config reload failed", slog.String("error", err.Error()))
+			}
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// Reload читает, проверяет и применяет конфигурацию. Некорректная
+// конфигурация не применяется: воркер продолжает работать со старой.
+// Если хотя бы один Applier вернул ошибку, уже примененные компоненты
+// возвращаются к прежней конфигурации и она остается текущей.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.path != "" {
+		if info, err := os.Stat(r.path); err == nil {
+			r.modTime = info.ModTime()
+		}
+	}
+
+	next, err := config.LoadReloadable(r.ctx, r.path)
+	if err != nil {
+		return err
+	}
+	if err := validate(next, r.jobTypes); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	diff := next.Diff(r.current)
+	if len(diff) == 0 {
+		slog.Info("Config reloaded, no changes")
+		return nil
+	}
+
+	var (
+		errs    []error
+		applied []Applier
+	)
+	for _, apply := range r.appliers {
+		if err := apply(next); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		applied = append(applied, apply)
+	}
+	if len(errs) > 0 {
+		for _, apply := range applied {
+			if err := apply(r.current); err != nil {
+				errs = append(errs, fmt.Errorf("rollback: %w", err))
+			}
+		}
+		return fmt.Errorf("config not applied: %w", errors.Join(errs...))
+	}
+	r.current = next
+
+	slog.Info("Config reloaded", slog.Any("changes", diff))
+	return nil
+}
+
+// Current возвращает последнюю примененную конфигурацию.
+func (r *Reloader) Current() config.Reloadable {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.current
+}
+
+func (r *Reloader) fileChanged() bool {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		slog.Warn("Config file is not accessible",
+			slog.String("path", r.path),
+			slog.String("error", err.Error()),
+		)
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return !info.ModTime().Equal(r.modTime)
+}
+
+func validate(cfg config.Reloadable, jobTypes map[string]config.JobTypeConfig) error {
+	errs := []error{cfg.Validate(), cfg.ValidateJobTypes(jobTypes)}
+	if _, err := ratelimit.ParseRules(cfg.RateLimitJobTypes); err != nil {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_JOB_TYPES: %w", err))
+	}
+	if _, err := ratelimit.ParseRules(cfg.RateLimitHosts); err != nil {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_HOSTS: %w", err))
+	}
+	return errors.Join(errs...)
+}
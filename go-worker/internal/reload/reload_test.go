@@ -0,0 +1,132 @@
+package reload_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/reload"
+)
+
+func TestReloadAppliesOnlyValidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worker.env")
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 10,
+			MaxJobTimeout:  30 * time.Second,
+			LogLevel:       "info",
+		},
+		ReloadFile: path,
+	}
+
+	var applied []config.Reloadable
+	r := reload.NewReloader(cfg, context.Background())
+	r.OnReload(func(c config.Reloadable) error {
+		applied = append(applied, c)
+		return nil
+	})
+
+	writeFile(t, path, "WORKER_POOL_SIZE=3\nLOG_LEVEL=debug\nRATE_LIMIT_HOSTS=*=5\n")
+	if err := r.Reload(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if len(applied) != 1 || applied[0].WorkerPoolSize != 3 || applied[0].LogLevel != "debug" {
+		t.Fatalf("expected new config to be applied, got %+v", applied)
+	}
+
+	writeFile(t, path, "WORKER_POOL_SIZE=0\nRATE_LIMIT_HOSTS=*=fast\n")
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected validation error")
+	}
+	if len(applied) != 1 || r.Current().WorkerPoolSize != 3 {
+		t.Errorf("invalid config must not be applied, current %+v", r.Current())
+	}
+}
+
+func TestReloadRollsBackOnApplierError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worker.env")
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 10,
+			MaxJobTimeout:  30 * time.Second,
+			LogLevel:       "info",
+		},
+		ReloadFile: path,
+	}
+
+	var poolSize int
+	r := reload.NewReloader(cfg, context.Background())
+	r.OnReload(func(c config.Reloadable) error {
+		poolSize = c.WorkerPoolSize
+		return nil
+	})
+	r.OnReload(func(c config.Reloadable) error {
+		if c.LogLevel == "debug" {
+			return errors.New("rate limiter rejected rules")
+		}
+		return nil
+	})
+
+	writeFile(t, path, "WORKER_POOL_SIZE=3\nLOG_LEVEL=debug\n")
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected applier error")
+	}
+	if poolSize != 10 {
+		t.Errorf("applied component must be rolled back, pool size %d", poolSize)
+	}
+	if r.Current().WorkerPoolSize != 10 || r.Current().LogLevel != "info" {
+		t.Errorf("previous config must stay current, got %+v", r.Current())
+	}
+
+	// Повторная попытка после исправления применяет конфигурацию целиком
+	writeFile(t, path, "WORKER_POOL_SIZE=3\nLOG_LEVEL=warn\n")
+	if err := r.Reload(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if poolSize != 3 || r.Current().WorkerPoolSize != 3 {
+		t.Errorf("expected new config to be applied, pool size %d, current %+v", poolSize, r.Current())
+	}
+}
+
+func TestReloadChecksJobTypeTimeouts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worker.env")
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 10,
+			MaxJobTimeout:  30 * time.Second,
+			LogLevel:       "info",
+		},
+		ReloadFile: path,
+		JobTypes:   map[string]config.JobTypeConfig{"HTTP_GET": {Timeout: 20 * time.Second}},
+	}
+
+	r := reload.NewReloader(cfg, context.Background())
+	writeFile(t, path, "MAX_JOB_TIMEOUT=10s\n")
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected error: job_types.HTTP_GET.timeout exceeds new MAX_JOB_TIMEOUT")
+	}
+	if r.Current().MaxJobTimeout != 30*time.Second {
+		t.Errorf("invalid config must not be applied, current %+v", r.Current())
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := config.Reloadable{WorkerPoolSize: 10, LogLevel: "info"}
+	next := config.Reloadable{WorkerPoolSize: 20, LogLevel: "info"}
+
+	diff := next.Diff(old)
+	if len(diff) != 1 || diff[0] != "WORKER_POOL_SIZE: 10 -> 20" {
+		t.Errorf("unexpected diff %v", diff)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,123 @@
+// Package resultsink — получатели результатов задач помимо управляющего
+// сервиса (см. worker.ResultSink).
+package resultsink
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/breaker"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/kafkaconn"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	kafkaBatchTimeout = 10 * time.Millisecond // Результаты пишутся синхронно, ждать заполнения батча не нужно
+	kafkaMaxAttempts  = 3
+)
+
+// KafkaSink публикует результаты в KAFKA_RESULTS_TOPIC как сообщения
+// JobResult с ключом job_id: результаты одной задачи попадают в одну партицию.
+type KafkaSink struct {
+	writer   *kafka.Writer
+	timeout  time.Duration
+	workerID string
+	breaker  *breaker.Breaker
+}
+
+func NewKafkaSink(cfg *config.Config) (*KafkaSink, error) {
+	transport, err := kafkaconn.Transport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(cfg.KafkaBrokersList...),
+		Topic:        cfg.KafkaResultsTopic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		MaxAttempts:  kafkaMaxAttempts,
+		BatchTimeout: kafkaBatchTimeout,
+		WriteTimeout: cfg.KafkaWriteTimeout,
+		Transport:    transport,
+	}
+
+	return &KafkaSink{
+		writer:   writer,
+		timeout:  cfg.KafkaWriteTimeout,
+		workerID: cfg.WorkerID,
+		breaker:  breaker.New("kafka:"+cfg.KafkaResultsTopic, breaker.SettingsFromConfig(cfg)),
+	}, nil
+}
+
+func (s *KafkaSink) Name() string {
+	return "kafka"
+}
+
+// Send публикует результат и ждет подтверждения от всех реплик.
+func (s *KafkaSink) Send(ctx context.Context, result models.JobResult) error {
+	// Ошибка кодирования не говорит о доступности Kafka и повтором не исправится
+	value, err := proto.Marshal(toProto(result, s.workerID))
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	// Пока Kafka недоступна, результаты сразу уходят в spool
+	done, err := s.breaker.Allow()
+	if err != nil {
+		return err
+	}
+
+	msg := kafka.Message{
+		Key:   []byte(strconv.FormatInt(result.JobID, 10)),
+		Value: value,
+	}
+	// Потребители результатов продолжают трассу задачи
+	otel.GetTextMapPropagator().Inject(ctx, (*tracing.KafkaHeaderCarrier)(&msg.Headers))
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	err = s.writer.WriteMessages(ctx, msg)
+	done(breaker.OutcomeOf(err == nil))
+	if err != nil {
+		return fmt.Errorf("failed to publish result to kafka: %w", err)
+	}
+	return nil
+}
+
+// Healthy сообщает, можно ли сейчас публиковать результаты: breaker не разомкнут.
+func (s *KafkaSink) Healthy() bool {
+	return s.breaker.Ready()
+}
+
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
+
+func toProto(result models.JobResult, workerID string) *pb.JobResult {
+	status := pb.JobResult_FAILED
+	if result.Status == models.StatusCompleted {
+		status = pb.JobResult_COMPLETED
+	}
+
+	return &pb.JobResult{
+		JobId:        result.JobID,
+		JobType:      string(result.Type),
+		Status:       status,
+		Result:       string(result.Result),
+		ErrorMessage: result.Error,
+		Retryable:    result.Retryable,
+		DurationMs:   result.Duration.Milliseconds(),
+		Attempt:      int32(result.Attempt),
+		WorkerId:     workerID,
+	}
+}
@@ -0,0 +1,68 @@
+package resultsink
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/breaker"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+func TestToProto(t *testing.T) {
+	got := toProto(models.JobResult{
+		JobID:     7,
+		Type:      models.JobTypeHttpGet,
+		Status:    models.StatusFailed,
+		Result:    json.RawMessage(`{"code":502}`),
+		Error:     "bad gateway",
+		Retryable: true,
+		Duration:  1500 * time.Millisecond,
+		Attempt:   2,
+	}, "worker-a")
+
+	if got.GetJobId() != 7 || got.GetJobType() != "HTTP_GET" || got.GetStatus() != pb.JobResult_FAILED {
+		t.Errorf("unexpected identity fields %v", got)
+	}
+	if got.GetResult() != `{"code":502}` || got.GetErrorMessage() != "bad gateway" || !got.GetRetryable() {
+		t.Errorf("unexpected outcome fields %v", got)
+	}
+	if got.GetDurationMs() != 1500 || got.GetAttempt() != 2 || got.GetWorkerId() != "worker-a" {
+		t.Errorf("unexpected execution fields %v", got)
+	}
+
+	if toProto(models.JobResult{Status: models.StatusCompleted}, "").GetStatus() != pb.JobResult_COMPLETED {
+		t.Error("expected COMPLETED status")
+	}
+}
+
+func TestEncodeErrorDoesNotOpenBreaker(t *testing.T) {
+	sink, err := NewKafkaSink(&config.Config{
+		KafkaBrokersList:        []string{"127.0.0.1:1"},
+		KafkaResultsTopic:       "job_results",
+		KafkaWriteTimeout:       time.Second,
+		BreakerFailureThreshold: 1,
+		BreakerOpenTimeout:      time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	// Строка с невалидным UTF-8 не кодируется в proto3
+	result := models.JobResult{JobID: 1, Status: models.StatusFailed, Error: "\xff"}
+	for range 3 {
+		if err := sink.Send(context.Background(), result); err == nil {
+			t.Fatal("expected encode error")
+		}
+	}
+	if state := sink.breaker.State(); state != breaker.StateClosed {
+		t.Errorf("encode errors must not affect breaker, state %s", state)
+	}
+	if !sink.Healthy() {
+		t.Error("sink must stay healthy after encode errors")
+	}
+}
@@ -0,0 +1,30 @@
+package spool
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	bytesGauge = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "results_spool",
+		Name:      "bytes",
+		Help:      "Size of results spool segment files on disk.",
+	}, []string{"spool"})
+
+	recordsGauge = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "results_spool",
+		Name:      "records",
+		Help:      "Results waiting in the spool to be delivered.",
+	}, []string{"spool"})
+
+	corruptedTotal = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "results_spool",
+		Name:      "corrupted_records_total",
+		Help:      "Spool records dropped because of checksum or length mismatch.",
+	}, []string{"spool"})
+)
@@ -0,0 +1,505 @@
+// Package spool — журнал записей на диске (write-ahead spool): записи
+// дописываются в сегменты и читаются в порядке записи. Каждая запись хранится
+// с длиной и контрольной суммой, позиция чтения сохраняется в файле курсора,
+// поэтому недочитанные записи переживают перезапуск процесса.
+//
+// Доставка — at-least-once: запись, прочитанная, но не подтвержденная до
+// падения процесса, будет прочитана снова.
+package spool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	headerSize = 8 // Длина (uint32) и CRC32 (uint32) записи
+	segmentExt = ".seg"
+	cursorFile = "cursor"
+)
+
+var (
+	ErrFull  = errors.New("spool is full")
+	ErrEmpty = errors.New("spool is empty")
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+type segment struct {
+	seq     uint64
+	size    int64
+	records int // Неподтвержденные записи сегмента
+}
+
+// Spool безопасен для конкурентного использования: обычно один писатель
+// (Append) и один читатель (Next/Ack).
+type Spool struct {
+	name         string
+	dir          string
+	maxBytes     int64
+	segmentBytes int64
+
+	mu       sync.Mutex
+	segments []segment // По возрастанию seq, последний — активный для записи
+	active   *os.File
+	reader   *os.File // Открытый на чтение segments[0]
+	readOff  int64    // Позиция чтения в segments[0]
+	pending  int64    // Длина записи, отданной Next и еще не подтвержденной
+	size     int64
+	records  int
+}
+
+// Open открывает spool в каталоге dir, создавая его при необходимости.
+// name различает spool'ы в логах и метриках.
+// Записи с неверной контрольной суммой или длиной (например, недописанные
+// при падении) отбрасываются вместе с остатком сегмента.
+func Open(name, dir string, maxBytes, segmentBytes int64) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create spool dir: %w", err)
+	}
+
+	s := &Spool{name: name, dir: dir, maxBytes: maxBytes, segmentBytes: segmentBytes}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	last := s.segments[len(s.segments)-1]
+	active, err := os.OpenFile(s.segmentPath(last.seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spool segment: %w", err)
+	}
+	s.active = active
+	s.updateMetrics()
+
+	if s.records > 0 {
+		slog.Info("Results spool has undelivered records",
+			slog.String("spool", name),
+			slog.String("dir", dir),
+			slog.Int("records", s.records),
+			slog.Int64("bytes", s.size),
+		)
+	}
+	return s, nil
+}
+
+// load читает курсор и проверяет сегменты.
+func (s *Spool) load() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read spool dir: %w", err)
+	}
+
+	var seqs []uint64
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), segmentExt)
+		if !ok || e.IsDir() {
+			continue
+		}
+		seq, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	slices.Sort(seqs)
+
+	cursorSeq, cursorOff, err := s.readCursor()
+	if err != nil {
+		return err
+	}
+
+	for _, seq := range seqs {
+		if seq < cursorSeq {
+			// Сегмент полностью прочитан, но не удален до остановки
+			if err := os.Remove(s.segmentPath(seq)); err != nil {
+				return fmt.Errorf("failed to remove spool segment: %w", err)
+			}
+			continue
+		}
+
+		from := int64(0)
+		if seq == cursorSeq {
+			from = cursorOff
+		}
+		seg, err := s.scan(seq, from)
+		if err != nil {
+			return err
+		}
+		if len(s.segments) == 0 {
+			s.readOff = min(from, seg.size)
+		}
+		s.segments = append(s.segments, seg)
+		s.size += seg.size
+		s.records += seg.records
+	}
+
+	// Номер нового сегмента не меньше курсора, иначе при следующем
+	// открытии сегмент будет принят за прочитанный
+	if len(s.segments) == 0 {
+		s.segments = append(s.segments, segment{seq: max(cursorSeq, 1)})
+	}
+	return nil
+}
+
+// scan считает записи сегмента начиная с from и обрезает сегмент
+// по первой поврежденной записи.
+func (s *Spool) scan(seq uint64, from int64) (segment, error) {
+	path := s.segmentPath(seq)
+	f, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	if err != nil {
+		return segment{}, fmt.Errorf("failed to open spool segment: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return segment{}, fmt.Errorf("failed to stat spool segment: %w", err)
+	}
+
+	seg := segment{seq: seq, size: info.Size()}
+	off := min(from, seg.size)
+	for off < seg.size {
+		data, err := readRecord(f, off, seg.size)
+		if err != nil {
+			slog.Warn("Truncating corrupted spool segment",
+				slog.String("segment", path),
+				slog.Int64("offset", off),
+				slog.String("error", err.Error()),
+			)
+			corruptedTotal.WithLabelValues(s.name).Inc()
+			if err := f.Truncate(off); err != nil {
+				return segment{}, fmt.Errorf("failed to truncate spool segment: %w", err)
+			}
+			seg.size = off
+			break
+		}
+		off += headerSize + int64(len(data))
+		seg.records++
+	}
+	return seg, nil
+}
+
+// Append дописывает запись и сбрасывает ее на диск.
+// Если места не хватает, возвращает ErrFull.
+func (s *Spool) Append(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := headerSize + int64(len(data))
+	if s.size+n > s.maxBytes {
+		return ErrFull
+	}
+
+	last := &s.segments[len(s.segments)-1]
+	if last.size > 0 && last.size+n > s.segmentBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+		last = &s.segments[len(s.segments)-1]
+	}
+
+	buf := make([]byte, n)
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.Checksum(data, crcTable))
+	copy(buf[headerSize:], data)
+
+	if _, err := s.active.Write(buf); err != nil {
+		return fmt.Errorf("failed to write spool record: %w", err)
+	}
+	if err := s.active.Sync(); err != nil {
+		return fmt.Errorf("failed to sync spool segment: %w", err)
+	}
+
+	last.size += n
+	last.records++
+	s.size += n
+	s.records++
+	s.updateMetrics()
+	return nil
+}
+
+// Next возвращает самую старую неподтвержденную запись, не удаляя ее.
+// Повторный вызов без Ack вернет ту же запись. Если записей нет — ErrEmpty.
+func (s *Spool) Next() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		head := &s.segments[0]
+		if s.readOff >= head.size {
+			if len(s.segments) == 1 {
+				return nil, ErrEmpty
+			}
+			if err := s.dropHead(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if s.reader == nil {
+			f, err := os.Open(s.segmentPath(head.seq))
+			if err != nil {
+				return nil, fmt.Errorf("failed to open spool segment: %w", err)
+			}
+			s.reader = f
+		}
+
+		data, err := readRecord(s.reader, s.readOff, head.size)
+		if err != nil {
+			// Длине после поврежденной записи доверять нельзя: пропускаем остаток сегмента
+			slog.Error("Skipping corrupted spool segment",
+				slog.String("segment", s.segmentPath(head.seq)),
+				slog.Int64("offset", s.readOff),
+				slog.Int("records", head.records),
+				slog.String("error", err.Error()),
+			)
+			corruptedTotal.WithLabelValues(s.name).Inc()
+			s.records -= head.records
+			head.records = 0
+			s.readOff = head.size
+			s.updateMetrics()
+			continue
+		}
+
+		s.pending = headerSize + int64(len(data))
+		return data, nil
+	}
+}
+
+// Ack подтверждает запись, возвращенную последним Next.
+func (s *Spool) Ack() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending == 0 {
+		return nil
+	}
+	s.readOff += s.pending
+	s.pending = 0
+	s.segments[0].records--
+	s.records--
+
+	// Полностью прочитанный сегмент освобождает место сразу
+	if s.readOff >= s.segments[0].size {
+		if len(s.segments) == 1 {
+			if err := s.rotate(); err != nil {
+				return err
+			}
+		}
+		if err := s.dropHead(); err != nil {
+			return err
+		}
+	} else if err := s.writeCursor(); err != nil {
+		return err
+	}
+
+	s.updateMetrics()
+	return nil
+}
+
+// Len возвращает число неподтвержденных записей.
+func (s *Spool) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.records
+}
+
+// Close закрывает файлы сегментов. Недочитанные записи остаются на диске.
+func (s *Spool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.reader != nil {
+		s.reader.Close()
+		s.reader = nil
+	}
+	return s.active.Close()
+}
+
+// rotate закрывает активный сегмент и начинает новый. Вызывается под s.mu.
+func (s *Spool) rotate() error {
+	if err := s.active.Close(); err != nil {
+		return fmt.Errorf("failed to close spool segment: %w", err)
+	}
+
+	seq := s.segments[len(s.segments)-1].seq + 1
+	active, err := os.OpenFile(s.segmentPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create spool segment: %w", err)
+	}
+	s.active = active
+	s.segments = append(s.segments, segment{seq: seq})
+	return nil
+}
+
+// dropHead удаляет прочитанный первый сегмент. Вызывается под s.mu.
+func (s *Spool) dropHead() error {
+	head := s.segments[0]
+	if s.reader != nil {
+		s.reader.Close()
+		s.reader = nil
+	}
+
+	s.segments = s.segments[1:]
+	s.readOff = 0
+	s.size -= head.size
+
+	// Курсор сохраняется до удаления: после падения между ними сегмент
+	// с меньшим номером будет удален при открытии
+	if err := s.writeCursor(); err != nil {
+		return err
+	}
+	if err := os.Remove(s.segmentPath(head.seq)); err != nil {
+		return fmt.Errorf("failed to remove spool segment: %w", err)
+	}
+	return nil
+}
+
+func (s *Spool) readCursor() (uint64, int64, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, cursorFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read spool cursor: %w", err)
+	}
+
+	var seq uint64
+	var off int64
+	if _, err := fmt.Sscanf(string(data), "%d %d", &seq, &off); err != nil {
+		return 0, 0, fmt.Errorf("invalid spool cursor %q: %w", data, err)
+	}
+	return seq, off, nil
+}
+
+// writeCursor атомарно сохраняет позицию чтения. Вызывается под s.mu.
+func (s *Spool) writeCursor() error {
+	path := filepath.Join(s.dir, cursorFile)
+	tmp := path + ".tmp"
+	data := fmt.Sprintf("%d %d\n", s.segments[0].seq, s.readOff)
+	if err := os.WriteFile(tmp, []byte(data), 0o600); err != nil {
+		return fmt.Errorf("failed to write spool cursor: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write spool cursor: %w", err)
+	}
+	return nil
+}
+
+func (s *Spool) segmentPath(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, segmentExt))
+}
+
+// updateMetrics вызывается под s.mu.
+func (s *Spool) updateMetrics() {
+	bytesGauge.WithLabelValues(s.name).Set(float64(s.size))
+	recordsGauge.WithLabelValues(s.name).Set(float64(s.records))
+}
+
+// readRecord читает запись по смещению off и проверяет ее длину и контрольную сумму.
+func readRecord(r io.ReaderAt, off, size int64) ([]byte, error) {
+	if size-off < headerSize {
+		return nil, errors.New("truncated record header")
+	}
+
+	var header [headerSize]byte
+	if _, err := r.ReadAt(header[:], off); err != nil {
+		return nil, err
+	}
+	n := int64(binary.BigEndian.Uint32(header[0:4]))
+	if size-off-headerSize < n {
+		return nil, errors.New("truncated record")
+	}
+
+	data := make([]byte, n)
+	if _, err := r.ReadAt(data, off+headerSize); err != nil {
+		return nil, err
+	}
+	if crc32.Checksum(data, crcTable) != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, errors.New("checksum mismatch")
+	}
+	return data, nil
+}
+
+// Migrate переносит spool из каталога from в каталог to, например сегменты,
+// записанные в корень RESULTS_SPOOL_DIR до появления отдельных каталогов
+// получателей. Файлы сначала собираются во временном каталоге рядом с to,
+// который затем переименовывается в to: прерванный перенос продолжается
+// при следующем вызове. Если в to уже есть сегменты, spool'ы не смешиваются:
+// старые сегменты остаются в from, в лог пишется предупреждение.
+func Migrate(from, to string) error {
+	tmp := to + ".migrating"
+	files, err := spoolFiles(from)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(tmp); !hasSegments(files) && errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	existing, err := spoolFiles(to)
+	if err != nil {
+		return err
+	}
+	if hasSegments(existing) {
+		slog.Warn("Spool segments left in old location: destination spool is not empty",
+			slog.String("from", from),
+			slog.String("to", to),
+			slog.Int("files", len(files)),
+		)
+		return nil
+	}
+
+	if err := os.MkdirAll(tmp, 0o700); err != nil {
+		return fmt.Errorf("failed to migrate spool: %w", err)
+	}
+	for _, name := range files {
+		if err := os.Rename(filepath.Join(from, name), filepath.Join(tmp, name)); err != nil {
+			return fmt.Errorf("failed to migrate spool: %w", err)
+		}
+	}
+	// В to нет сегментов: остаться мог только курсор пустого spool'а
+	if err := os.RemoveAll(to); err != nil {
+		return fmt.Errorf("failed to migrate spool: %w", err)
+	}
+	if err := os.Rename(tmp, to); err != nil {
+		return fmt.Errorf("failed to migrate spool: %w", err)
+	}
+
+	slog.Info("Spool migrated", slog.String("from", from), slog.String("to", to))
+	return nil
+}
+
+func hasSegments(names []string) bool {
+	return slices.ContainsFunc(names, func(name string) bool { return strings.HasSuffix(name, segmentExt) })
+}
+
+// spoolFiles возвращает имена сегментов и курсора в каталоге dir.
+func spoolFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spool dir: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && (strings.HasSuffix(e.Name(), segmentExt) || e.Name() == cursorFile) {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
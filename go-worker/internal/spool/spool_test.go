@@ -0,0 +1,154 @@
+package spool_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/spool"
+)
+
+func TestSpoolReplaysInOrderAfterReopen(t *testing.T) {
+	dir := t.TempDir()
+
+	// Маленькие сегменты: записи распределяются по нескольким файлам
+	s, err := spool.Open("test", dir, 1<<20, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 5 {
+		if err := s.Append(fmt.Appendf(nil, "record-%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := range 2 {
+		data, err := s.Next()
+		if err != nil || string(data) != fmt.Sprintf("record-%d", i) {
+			t.Fatalf("expected record-%d, got %q (%v)", i, data, err)
+		}
+		if err := s.Ack(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Прочитана, но не подтверждена: после перезапуска будет прочитана снова
+	if _, err := s.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = spool.Open("test", dir, 1<<20, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if s.Len() != 3 {
+		t.Fatalf("expected 3 records after reopen, got %d", s.Len())
+	}
+	for i := 2; i < 5; i++ {
+		data, err := s.Next()
+		if err != nil || string(data) != fmt.Sprintf("record-%d", i) {
+			t.Fatalf("expected record-%d, got %q (%v)", i, data, err)
+		}
+		if err := s.Ack(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Next(); !errors.Is(err, spool.ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestSpoolDropsTornRecordAndLimitsSize(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := spool.Open("test", dir, 64, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Append([]byte("complete")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Append(make([]byte, 64)); !errors.Is(err, spool.ErrFull) {
+		t.Fatalf("expected ErrFull, got %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Недописанная при падении запись
+	segments, _ := filepath.Glob(filepath.Join(dir, "*.seg"))
+	f, err := os.OpenFile(segments[0], os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte{0, 0, 0, 42, 1, 2})
+	f.Close()
+
+	s, err = spool.Open("test", dir, 64, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if s.Len() != 1 {
+		t.Fatalf("expected torn record to be dropped, got %d records", s.Len())
+	}
+	if data, err := s.Next(); err != nil || string(data) != "complete" {
+		t.Fatalf("expected complete record, got %q (%v)", data, err)
+	}
+}
+
+func TestMigrateMovesSpoolToSubdir(t *testing.T) {
+	root := t.TempDir()
+	s, err := spool.Open("test", root, 1<<20, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		if err := s.Append(fmt.Appendf(nil, "record-%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Ack(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(root, "grpc")
+	if err := spool.Migrate(root, dir); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(root, "*.seg")); len(matches) != 0 {
+		t.Fatalf("expected segments moved out of root, left %v", matches)
+	}
+
+	s, err = spool.Open("test", dir, 1<<20, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	for i := 1; i < 3; i++ {
+		data, err := s.Next()
+		if err != nil || string(data) != fmt.Sprintf("record-%d", i) {
+			t.Fatalf("expected record-%d after migration, got %q (%v)", i, data, err)
+		}
+		if err := s.Ack(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Повторный перенос без старых сегментов ничего не делает
+	if err := spool.Migrate(root, dir); err != nil {
+		t.Fatalf("repeated migrate: %v", err)
+	}
+}
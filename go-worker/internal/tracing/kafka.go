@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaHeaderCarrier — propagation.TextMapCarrier поверх заголовков сообщения Kafka:
+//
+//	ctx = otel.GetTextMapPropagator().Extract(ctx, (*tracing.KafkaHeaderCarrier)(&msg.Headers))
+type KafkaHeaderCarrier []kafka.Header
+
+func (c *KafkaHeaderCarrier) Get(key string) string {
+	for _, h := range *c {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c *KafkaHeaderCarrier) Set(key, value string) {
+	for i, h := range *c {
+		if h.Key == key {
+			(*c)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c = append(*c, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c *KafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c))
+	for _, h := range *c {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
@@ -0,0 +1,44 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContextSurvivesKafkaAndJob(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), &config.Config{
+		TracingExporter:    "stdout",
+		TracingSampleRatio: 1,
+		TracingServiceName: "test",
+	})
+	if err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	defer func() { _ = shutdown(context.Background()) }()
+
+	// Продюсер кладет traceparent в заголовки сообщения
+	ctx, span := tracing.Tracer().Start(context.Background(), "producer")
+	defer span.End()
+	want := span.SpanContext().TraceID()
+
+	var headers []kafka.Header
+	otel.GetTextMapPropagator().Inject(ctx, (*tracing.KafkaHeaderCarrier)(&headers))
+	if len(headers) == 0 {
+		t.Fatal("expected trace headers in kafka message")
+	}
+
+	// Консьюмер извлекает контекст и передает его дальше через задачу
+	received := otel.GetTextMapPropagator().Extract(context.Background(), (*tracing.KafkaHeaderCarrier)(&headers))
+	jobHeaders := tracing.Inject(received)
+
+	got := trace.SpanContextFromContext(tracing.Extract(context.Background(), jobHeaders)).TraceID()
+	if got != want {
+		t.Errorf("trace id lost: want %s, got %s", want, got)
+	}
+}
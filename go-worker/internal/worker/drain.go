@@ -0,0 +1,173 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/consumer"
+)
+
+const drainPollInterval = 200 * time.Millisecond
+
+// DrainState — стадия drain воркера.
+type DrainState string
+
+const (
+	DrainNone     DrainState = "none"
+	DrainDraining DrainState = "draining"
+	DrainDrained  DrainState = "drained"
+)
+
+// Drainer выводит воркер из работы перед деплоем, не останавливая процесс:
+// чтение из Kafka ставится на паузу с сохранением членства в группе, взятые
+// задачи выполняются, результаты отправляются, после чего воркер сообщает
+// о неготовности. Drain запускается по SIGUSR1 или через admin API,
+// Resume (SIGUSR2 или admin API) возвращает воркер в работу.
+type Drainer struct {
+	consumer consumer.Consumer
+	pool     *WorkerPool
+	sender   *ResultSender
+
+	mu     sync.Mutex
+	state  DrainState
+	cancel context.CancelFunc // Прерывает текущий drain
+
+	wg  sync.WaitGroup
+	ctx context.Context
+}
+
+func NewDrainer(
+	cons consumer.Consumer,
+	pool *WorkerPool,
+	sender *ResultSender,
+	ctx context.Context,
+) *Drainer {
+	return &Drainer{
+		consumer: cons,
+		pool:     pool,
+		sender:   sender,
+		state:    DrainNone,
+		ctx:      ctx,
+	}
+}
+
+// Start начинает ждать SIGUSR1 (drain) и SIGUSR2 (resume). Сигналы
+// перехватываются до возврата из Start.
+func (d *Drainer) Start() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	d.wg.Add(1)
+	go d.run(signals)
+}
+
+// Stop ожидает завершения обработчика сигнала и текущего drain (после отмены контекста).
+func (d *Drainer) Stop() {
+	d.wg.Wait()
+}
+
+func (d *Drainer) run(signals chan os.Signal) {
+	defer d.wg.Done()
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case sig := <-signals:
+			if sig == syscall.SIGUSR2 {
+				slog.Info("SIGUSR2 received, resuming worker")
+				d.Resume()
+				continue
+			}
+			slog.Info("SIGUSR1 received, draining worker")
+			d.Drain()
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// Drain запускает drain в фоне. Повторный вызов во время drain ничего не делает.
+func (d *Drainer) Drain() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.state != DrainNone {
+		return
+	}
+	d.state = DrainDraining
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	d.cancel = cancel
+	d.wg.Add(1)
+	go d.drain(ctx)
+}
+
+// Resume прерывает drain или выводит воркер из состояния drained
+// и возобновляет чтение.
+func (d *Drainer) Resume() {
+	d.mu.Lock()
+	if d.cancel != nil {
+		d.cancel()
+		d.cancel = nil
+	}
+	d.state = DrainNone
+	d.mu.Unlock()
+
+	d.consumer.Resume()
+}
+
+// State возвращает стадию drain.
+func (d *Drainer) State() DrainState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.state
+}
+
+// Ready сообщает, готов ли воркер принимать задачи: после drain — нет.
+func (d *Drainer) Ready() bool {
+	return d.State() != DrainDrained
+}
+
+func (d *Drainer) drain(ctx context.Context) {
+	defer d.wg.Done()
+
+	// Прочитанное сообщение передается в пул и коммитится
+	if err := d.consumer.Drain(ctx); err != nil {
+		return
+	}
+
+	// Пул и отправитель (вместе со spool получателей) проверяются два раза
+	// подряд: в момент одной проверки
+	// задача или результат могут быть уже вынуты из канала, но еще не учтены
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	idleChecks := 0
+	for idleChecks < 2 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if d.pool.Idle() && d.sender.Idle() {
+			idleChecks++
+		} else {
+			idleChecks = 0
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if ctx.Err() == nil {
+		d.state = DrainDrained
+		slog.Info("Worker drained: in-flight jobs finished, results sent, reporting not ready")
+	}
+}
@@ -0,0 +1,178 @@
+package worker_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/spool"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/worker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pausableConsumer — consumer, который только запоминает паузу.
+type pausableConsumer struct {
+	mu     sync.Mutex
+	paused bool
+}
+
+func (c *pausableConsumer) Start(context.Context) error { return nil }
+func (c *pausableConsumer) Close() error                { return nil }
+func (c *pausableConsumer) Pause()                      { c.setPaused(true) }
+func (c *pausableConsumer) Resume()                     { c.setPaused(false) }
+func (c *pausableConsumer) Drain(context.Context) error { c.setPaused(true); return nil }
+
+func (c *pausableConsumer) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+func (c *pausableConsumer) setPaused(paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = paused
+}
+
+// switchableSink — получатель, который можно сделать недоступным.
+type switchableSink struct {
+	down atomic.Bool
+	sent atomic.Int32
+}
+
+func (s *switchableSink) Name() string  { return "grpc" }
+func (s *switchableSink) Healthy() bool { return !s.down.Load() }
+func (s *switchableSink) Send(context.Context, models.JobResult) error {
+	if s.down.Load() {
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+	s.sent.Add(1)
+	return nil
+}
+
+func waitState(t *testing.T, d *worker.Drainer, want worker.DrainState) {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for d.State() != want {
+		select {
+		case <-deadline:
+			t.Fatalf("drain state %s, want %s", d.State(), want)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestDrainerWaitsForJobsAndResumesOnSignal(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  5 * time.Second,
+		},
+	}
+	jobs := make(chan models.Job, 1)
+	results := make(chan models.JobResult, 1)
+	release := make(chan struct{})
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(context.Context, string) (any, error) {
+			<-release
+			return nil, nil
+		},
+	}
+	sink := &switchableSink{}
+	cons := &pausableConsumer{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, nil, nil, nil, ctx)
+	rs := worker.NewResultSender([]worker.ResultDestination{{Sink: sink}}, noProgress{}, results, nil, ctx)
+	d := worker.NewDrainer(cons, wp, rs, ctx)
+	wp.Start()
+	rs.Start()
+	d.Start()
+	defer func() {
+		cancel()
+		d.Stop()
+		close(jobs)
+		wp.Stop(context.Background())
+		rs.Stop(context.Background())
+	}()
+
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep}
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitState(t, d, worker.DrainDraining)
+	if !cons.Paused() {
+		t.Error("consumer must be paused while draining")
+	}
+
+	// Пока задача выполняется, drain не завершается
+	time.Sleep(500 * time.Millisecond)
+	if d.State() != worker.DrainDraining || !d.Ready() {
+		t.Fatalf("drain must wait for in-flight job, state %s", d.State())
+	}
+
+	close(release)
+	waitState(t, d, worker.DrainDrained)
+	if d.Ready() {
+		t.Error("drained worker must not be ready")
+	}
+	if sink.sent.Load() != 1 {
+		t.Errorf("expected result sent before drained, got %d", sink.sent.Load())
+	}
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatal(err)
+	}
+	waitState(t, d, worker.DrainNone)
+	if cons.Paused() || !d.Ready() {
+		t.Error("SIGUSR2 must resume consumer and readiness")
+	}
+}
+
+func TestResultSenderNotIdleWhileSpoolHasResults(t *testing.T) {
+	sp, err := spool.Open("test", t.TempDir(), 1<<20, 1<<10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sp.Close()
+
+	sink := &switchableSink{}
+	sink.down.Store(true)
+	results := make(chan models.JobResult, 1)
+	rs := worker.NewResultSender([]worker.ResultDestination{{Sink: sink, Spool: sp}}, noProgress{}, results, nil, context.Background())
+	rs.Start()
+	defer rs.Stop(context.Background())
+
+	results <- models.JobResult{JobID: 1, Status: models.StatusCompleted}
+	deadline := time.After(3 * time.Second)
+	for sp.Len() == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("result was not spooled")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if rs.Idle() {
+		t.Fatal("sender with spooled results must not be idle")
+	}
+
+	// Получатель снова доступен: spool досылается, отправитель простаивает
+	sink.down.Store(false)
+	for !rs.Idle() {
+		select {
+		case <-deadline:
+			t.Fatalf("spool was not replayed, %d records left", sp.Len())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if sink.sent.Load() != 1 {
+		t.Errorf("expected spooled result delivered, got %d", sink.sent.Load())
+	}
+}
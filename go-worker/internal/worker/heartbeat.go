@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/lease"
+)
+
+// ErrLeaseRevoked — причина отмены контекста задачи, аренду которой отозвал сервер.
+var ErrLeaseRevoked = errors.New("job lease revoked")
+
+func (wp *WorkerPool) runHeartbeats() {
+	defer wp.bgWg.Done()
+
+	ticker := time.NewTicker(wp.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wp.stopChan:
+			return
+		case <-wp.ctx.Done():
+			return
+		case <-ticker.C:
+			if !wp.sendHeartbeats() {
+				return
+			}
+		}
+	}
+}
+
+// sendHeartbeats продлевает аренду выполняемых задач. Возвращает false,
+// если сервер не поддерживает heartbeat'ы и отправлять их больше не нужно.
+func (wp *WorkerPool) sendHeartbeats() bool {
+	for _, j := range wp.inFlight.snapshot() {
+		if j.job.Cron != "" {
+			// У задачи по расписанию нет аренды в управляющем сервисе
+			continue
+		}
+		elapsed := time.Since(j.startedAt)
+
+		// Шаг workflow продлевает аренду задачи WORKFLOW в попытке, где он запущен
+		attempt := j.job.Attempt
+		if j.job.Workflow != nil {
+			attempt = j.job.Workflow.Run
+		}
+
+		revoked, err := wp.heartbeater.Heartbeat(wp.ctx, j.job.ID, attempt, elapsed)
+		if errors.Is(err, lease.ErrHeartbeatsUnsupported) {
+			slog.Warn("Server does not support job heartbeats, lease renewal disabled")
+			return false
+		}
+		if err != nil {
+			slog.Warn("Failed to send heartbeat",
+				slog.Int64("job_id", j.job.ID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		if revoked {
+			slog.Warn("Job lease revoked, cancelling",
+				slog.Int64("job_id", j.job.ID),
+				slog.Duration("elapsed", elapsed),
+			)
+			j.cancel(ErrLeaseRevoked)
+		}
+	}
+	return true
+}
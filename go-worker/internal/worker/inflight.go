@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// ErrJobCancelled — причина отмены контекста задачи, которую отменил оператор.
+var ErrJobCancelled = errors.New("job cancelled by operator")
+
+// JobInfo — сведения о выполняемой задаче.
+type JobInfo struct {
+	JobID     int64
+	Step      string // Шаг workflow, если задача — шаг
+	Cron      string // Имя задачи по расписанию; у нее нет ID
+	Type      models.JobType
+	Attempt   int
+	Slot      int // Номер горутины пула
+	StartedAt time.Time
+}
+
+// inFlightJob — задача, которая сейчас выполняется одним из воркеров.
+type inFlightJob struct {
+	job       models.Job
+	slot      int
+	startedAt time.Time
+	cancel    context.CancelCauseFunc
+}
+
+// inFlight отслеживает выполняемые задачи пула по models.Job.Key:
+// шаги одного workflow выполняются под ID задачи WORKFLOW.
+type inFlight struct {
+	mu   sync.Mutex
+	jobs map[string]*inFlightJob
+}
+
+func newInFlight() *inFlight {
+	return &inFlight{jobs: make(map[string]*inFlightJob)}
+}
+
+func (f *inFlight) add(j *inFlightJob) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[j.job.Key()] = j
+}
+
+func (f *inFlight) remove(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.jobs, key)
+}
+
+func (f *inFlight) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.jobs)
+}
+
+// cancel отменяет с причиной cause выполнение задачи и всех ее шагов workflow.
+// Задачи по расписанию (без ID) так не отменяются.
+func (f *inFlight) cancel(jobID int64, cause error) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	found := false
+	for _, j := range f.jobs {
+		if j.job.ID == jobID && j.job.Cron == "" {
+			j.cancel(cause)
+			found = true
+		}
+	}
+	return found
+}
+
+// snapshot возвращает копию списка, чтобы не держать блокировку во время сетевых вызовов.
+func (f *inFlight) snapshot() []inFlightJob {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	res := make([]inFlightJob, 0, len(f.jobs))
+	for _, j := range f.jobs {
+		res = append(res, *j)
+	}
+	return res
+}
+
+// InFlightJobs возвращает выполняемые задачи в порядке начала выполнения.
+func (wp *WorkerPool) InFlightJobs() []JobInfo {
+	jobs := wp.inFlight.snapshot()
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].startedAt.Before(jobs[k].startedAt) })
+
+	res := make([]JobInfo, 0, len(jobs))
+	for _, j := range jobs {
+		var step string
+		if j.job.Workflow != nil {
+			step = j.job.Workflow.Step
+		}
+		res = append(res, JobInfo{
+			JobID:     j.job.ID,
+			Step:      step,
+			Cron:      j.job.Cron,
+			Type:      j.job.Type,
+			Attempt:   j.job.Attempt,
+			Slot:      j.slot,
+			StartedAt: j.startedAt,
+		})
+	}
+	return res
+}
+
+// Cancel прерывает выполняемую задачу. Ее результат отправляется как
+// неуспешный с ErrJobCancelled. Возвращает false, если задача не выполняется.
+func (wp *WorkerPool) Cancel(jobID int64) bool {
+	return wp.inFlight.cancel(jobID, ErrJobCancelled)
+}
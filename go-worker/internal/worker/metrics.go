@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	topicJobs = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "topic",
+		Name:      "jobs_total",
+		Help:      "Jobs finished by the worker pool, by Kafka topic and status.",
+	}, []string{"topic", "status"})
+
+	topicJobsRunning = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "topic",
+		Name:      "jobs_running",
+		Help:      "Jobs currently executed by the worker pool, by Kafka topic.",
+	}, []string{"topic"})
+
+	topicJobsQueued = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "topic",
+		Name:      "jobs_queued",
+		Help:      "Jobs waiting for a free concurrency slot of their Kafka topic.",
+	}, []string{"topic"})
+
+	topicJobsRejected = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "topic",
+		Name:      "jobs_rejected_total",
+		Help:      "Jobs rejected because their type is not allowed in the Kafka topic.",
+	}, []string{"topic", "job_type"})
+)
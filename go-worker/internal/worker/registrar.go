@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/buildinfo"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+const (
+	registrationRetryDelay    = time.Second
+	maxRegistrationRetryDelay = 30 * time.Second
+)
+
+// RegistrationClient доставляет сведения о воркере управляющему сервису.
+type RegistrationClient interface {
+	Register(ctx context.Context, info models.WorkerInfo) error
+}
+
+// Registrar регистрирует воркер при старте и затем периодически
+// повторяет регистрацию с текущей нагрузкой пула. Неудачная регистрация
+// повторяется с экспоненциальной задержкой, но не реже REGISTRATION_INTERVAL.
+type Registrar struct {
+	client    RegistrationClient
+	pool      *WorkerPool
+	workerID  string
+	interval  time.Duration
+	startedAt time.Time
+
+	ctx context.Context
+	wg  sync.WaitGroup
+}
+
+func NewRegistrar(
+	cfg *config.Config,
+	client RegistrationClient,
+	pool *WorkerPool,
+	ctx context.Context,
+) *Registrar {
+	return &Registrar{
+		client:    client,
+		pool:      pool,
+		workerID:  cfg.WorkerID,
+		interval:  cfg.RegistrationInterval,
+		startedAt: time.Now(),
+		ctx:       ctx,
+	}
+}
+
+// Start отправляет первую регистрацию и запускает периодическую.
+func (r *Registrar) Start() {
+	r.wg.Add(1)
+	go r.run()
+}
+
+// Stop ожидает завершения цикла регистрации (после отмены контекста).
+func (r *Registrar) Stop() {
+	r.wg.Wait()
+}
+
+func (r *Registrar) run() {
+	defer r.wg.Done()
+
+	failures := 0
+	for {
+		delay := r.interval
+		if r.register() {
+			failures = 0
+		} else {
+			delay = r.retryDelay(failures)
+			failures++
+		}
+		if delay <= 0 {
+			return
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-r.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay — задержка перед повтором после failures+1 неудачных регистраций подряд.
+func (r *Registrar) retryDelay(failures int) time.Duration {
+	delay := min(registrationRetryDelay<<min(failures, 8), maxRegistrationRetryDelay)
+	if r.interval > 0 {
+		delay = min(delay, r.interval)
+	}
+	return delay
+}
+
+// register отправляет регистрацию и сообщает, принята ли она.
+func (r *Registrar) register() bool {
+	inFlight, queued := r.pool.Load()
+	info := models.WorkerInfo{
+		WorkerID:  r.workerID,
+		Version:   buildinfo.GetVersion(),
+		JobTypes:  jobregistry.Names(),
+		PoolSize:  r.pool.Size(),
+		InFlight:  inFlight,
+		Queued:    queued,
+		StartedAt: r.startedAt,
+	}
+
+	if err := r.client.Register(r.ctx, info); err != nil {
+		slog.Warn("Failed to register worker", slog.String("error", err.Error()))
+		return false
+	}
+	slog.Debug("Worker registered",
+		slog.Int("in_flight", inFlight),
+		slog.Int("queued", queued),
+	)
+	return true
+}
@@ -0,0 +1,105 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/worker"
+)
+
+// flakyRegistrationClient отклоняет первые fail регистраций.
+type flakyRegistrationClient struct {
+	fail int
+
+	mu    sync.Mutex
+	calls []time.Time
+	infos []models.WorkerInfo
+}
+
+func (c *flakyRegistrationClient) Register(_ context.Context, info models.WorkerInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, time.Now())
+	if len(c.calls) <= c.fail {
+		return errors.New("control service unavailable")
+	}
+	c.infos = append(c.infos, info)
+	return nil
+}
+
+func (c *flakyRegistrationClient) snapshot() ([]time.Time, []models.WorkerInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Time(nil), c.calls...), append([]models.WorkerInfo(nil), c.infos...)
+}
+
+func newRegistrar(t *testing.T, interval time.Duration, client worker.RegistrationClient) (*worker.Registrar, context.CancelFunc) {
+	t.Helper()
+
+	cfg := &config.Config{
+		Reloadable:           config.Reloadable{WorkerPoolSize: 2, MaxJobTimeout: time.Second},
+		WorkerID:             "worker-a",
+		RegistrationInterval: interval,
+	}
+	pool := worker.NewWorkerPool(cfg, make(chan models.Job), make(chan models.JobResult), nil, nil, nil, nil, nil, context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	return worker.NewRegistrar(cfg, client, pool, ctx), cancel
+}
+
+func TestRegistrarReRegistersPeriodically(t *testing.T) {
+	client := &flakyRegistrationClient{fail: 1}
+	r, cancel := newRegistrar(t, 20*time.Millisecond, client)
+	r.Start()
+
+	deadline := time.After(time.Second)
+	for {
+		if _, infos := client.snapshot(); len(infos) >= 3 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("worker was not re-registered after a failed registration")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	cancel()
+	r.Stop()
+
+	_, infos := client.snapshot()
+	if infos[0].WorkerID != "worker-a" {
+		t.Errorf("unexpected registration: %+v", infos[0])
+	}
+}
+
+func TestRegistrarRetriesFailedRegistrationWithBackoff(t *testing.T) {
+	// REGISTRATION_INTERVAL=0: регистрация только при старте, но неудачная повторяется
+	client := &flakyRegistrationClient{fail: 1}
+	r, cancel := newRegistrar(t, 0, client)
+	defer cancel()
+	r.Start()
+
+	done := make(chan struct{})
+	go func() {
+		r.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("registrar did not finish after a successful retry")
+	}
+
+	calls, infos := client.snapshot()
+	if len(calls) != 2 || len(infos) != 1 {
+		t.Fatalf("expected one failed and one successful registration, got %d calls", len(calls))
+	}
+	if gap := calls[1].Sub(calls[0]); gap < 900*time.Millisecond {
+		t.Errorf("expected retry after backoff, got %s", gap)
+	}
+}
@@ -2,42 +2,128 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 
-	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/grpc"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/joblog"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/spool"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	spoolReplayInterval    = time.Second // Период проверки spool, пока получатель недоступен
+	spoolFullRetryInterval = time.Second
+)
+
+// ProgressSink — получатель промежуточного прогресса задач (управляющий сервис).
+type ProgressSink interface {
+	HandleProgress(p models.JobProgress) error
+}
+
+// ResultSender доставляет результаты во все получатели (ResultSink). Результаты,
+// которые не удалось доставить получателю, сохраняются в его spool на диске
+// и досылаются по порядку в фоне, когда получатель снова доступен.
 type ResultSender struct {
-	resultHandler *grpc.ResultHandler
-	resultsChan   <-chan models.JobResult
-	ctx           context.Context
-	wg            sync.WaitGroup
+	destinations []ResultDestination
+	progressSink ProgressSink
+	resultsChan  <-chan models.JobResult
+	progressChan <-chan models.JobProgress
+	wg           sync.WaitGroup
+
+	// Контекст отправки: отменяется, если Stop не успел отправить результаты до дедлайна
+	ctx    context.Context
+	cancel context.CancelFunc
+	flush  chan struct{} // Закрывается в Stop
+	unsent []models.JobResult
+
+	sending atomic.Bool // Результат получен из канала и отправляется
 }
 
 func NewResultSender(
-	resultHandler *grpc.ResultHandler,
+	destinations []ResultDestination,
+	progressSink ProgressSink,
 	resultsChan <-chan models.JobResult,
+	progressChan <-chan models.JobProgress,
 	ctx context.Context,
 ) *ResultSender {
+	ctx, cancel := context.WithCancel(ctx)
 	return &ResultSender{
-		resultHandler: resultHandler,
-		resultsChan:   resultsChan,
-		ctx:           ctx,
+		destinations: destinations,
+		progressSink: progressSink,
+		resultsChan:  resultsChan,
+		progressChan: progressChan,
+		ctx:          ctx,
+		cancel:       cancel,
+		flush:        make(chan struct{}),
 	}
 }
 
-// Start запускает обработчик результатов.
+// Start запускает обработчик результатов, отправку прогресса и досылку
+// из spool каждого получателя.
 func (rs *ResultSender) Start() {
 	rs.wg.Add(1)
 	go rs.run()
+
+	if rs.progressChan != nil {
+		rs.wg.Add(1)
+		go rs.runProgress()
+	}
+
+	for _, d := range rs.destinations {
+		if d.Spool != nil {
+			rs.wg.Add(1)
+			go rs.replay(d)
+		}
+	}
 }
 
-// Stop ожидает завершения обработки всех результатов.
-func (rs *ResultSender) Stop() {
-	rs.wg.Wait()
+// Idle сообщает, что все результаты из канала отправлены и spool
+// получателей пуст: отложенные результаты тоже доставлены.
+func (rs *ResultSender) Idle() bool {
+	if len(rs.resultsChan) != 0 || rs.sending.Load() {
+		return false
+	}
+	for _, d := range rs.destinations {
+		if d.Spool != nil && d.Spool.Len() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Stop отправляет результаты, оставшиеся в канале, до дедлайна ctx. После
+// дедлайна результаты сохраняются в spool без попытки отправки. Возвращает
+// результаты, которые хотя бы одному получателю не удалось ни отправить, ни сохранить.
+// Вызывается после остановки пула; spool досылается после перезапуска.
+func (rs *ResultSender) Stop(ctx context.Context) []models.JobResult {
+	close(rs.flush)
+
+	done := make(chan struct{})
+	go func() {
+		rs.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// Текущая отправка прерывается, остальные результаты не отправляются
+		slog.Warn("Result flush deadline exceeded")
+		rs.cancel()
+		<-done
+	}
+	rs.cancel()
+
+	return rs.unsent
 }
 
 func (rs *ResultSender) run() {
@@ -50,51 +136,247 @@ func (rs *ResultSender) run() {
 				slog.Info("Results channel closed, stopping sender")
 				return
 			}
-			rs.sendResult(result)
+			rs.sending.Store(true)
+			_ = rs.deliver(result)
+			rs.sending.Store(false)
 
-		case <-rs.ctx.Done():
-			slog.Info("Result sender stopped by context")
+		case <-rs.flush:
+			rs.flushResults()
 			return
 		}
 	}
 }
 
-func (rs *ResultSender) sendResult(result models.JobResult) {
-	var err error
+// runProgress отправляет прогресс отдельно от результатов: медленный
+// или недоступный управляющий сервис не задерживает доставку результатов.
+func (rs *ResultSender) runProgress() {
+	defer rs.wg.Done()
 
-	switch result.Status {
-	case models.StatusCompleted:
-		resultData := map[string]interface{}{
-			"result": result.Result,
-			"status": string(result.Status),
+	for {
+		select {
+		case p, ok := <-rs.progressChan:
+			if !ok {
+				return
+			}
+			rs.sendProgress(p)
+		case <-rs.flush:
+			return
+		case <-rs.ctx.Done():
+			return
 		}
-		err = rs.resultHandler.HandleSuccess(result.JobID, resultData)
+	}
+}
 
-	case models.StatusFailed:
-		err = rs.resultHandler.HandleFailure(result.JobID, fmt.Errorf("%s", result.Error))
+// flushResults отправляет результаты, оставшиеся в канале. После дедлайна
+// результаты сразу сохраняются в spool; то, что не удалось сохранить, копится в unsent.
+func (rs *ResultSender) flushResults() {
+	for {
+		select {
+		case result, ok := <-rs.resultsChan:
+			if !ok {
+				return
+			}
+			if err := rs.deliver(result); err != nil {
+				rs.unsent = append(rs.unsent, result)
+			}
+		default:
+			return
+		}
+	}
+}
 
-	case models.StatusCreated, models.StatusInProgress:
-		slog.Error("Invalid job status in results channel",
-			slog.Int64("job_id", result.JobID),
+// deliver доставляет результат во все получатели. Ошибка означает, что хотя бы
+// одному получателю результат не доставлен и не сохранен.
+func (rs *ResultSender) deliver(result models.JobResult) error {
+	if result.Status != models.StatusCompleted && result.Status != models.StatusFailed {
+		joblog.ForResult(rs.ctx, result).Error("Invalid job status in results channel",
 			slog.String("status", string(result.Status)),
 		)
-		return
+		return nil
+	}
 
-	default:
-		slog.Error("Unknown job status",
-			slog.Int64("job_id", result.JobID),
-			slog.String("status", string(result.Status)),
-		)
-		return
+	var errs []error
+	for _, d := range rs.destinations {
+		if err := rs.deliverTo(d, result); err != nil {
+			resultDeliveries.WithLabelValues(d.Sink.Name(), deliveryLost).Inc()
+			errs = append(errs, fmt.Errorf("%s: %w", d.Sink.Name(), err))
+		}
 	}
+	return errors.Join(errs...)
+}
 
+// deliverTo отправляет результат получателю, а при недоступности получателя
+// сохраняет его в spool. Результат, отклоненный получателем, не сохраняется.
+// Пока в spool есть записи, новые результаты встают за ними, чтобы получатель
+// видел их по порядку. После дедлайна остановки результат сразу сохраняется.
+func (rs *ResultSender) deliverTo(d ResultDestination, result models.JobResult) error {
+	if d.Spool == nil {
+		if rs.ctx.Err() != nil {
+			return rs.ctx.Err()
+		}
+		return rs.send(d.Sink, result)
+	}
+	if rs.ctx.Err() == nil && d.Spool.Len() == 0 {
+		err := rs.send(d.Sink, result)
+		if err == nil {
+			return nil
+		}
+		if !rs.retryable(err) {
+			return err
+		}
+	}
+	return rs.spoolResult(d, result)
+}
+
+// retryable сообщает, стоит ли повторить отправку позже: получатель
+// недоступен или отправка прервана остановкой.
+func (rs *ResultSender) retryable(err error) bool {
+	return rs.ctx.Err() != nil || transient(err)
+}
+
+// spoolResult сохраняет результат в spool получателя. Если spool заполнен, ждет,
+// пока досылка освободит место: отправитель не читает канал результатов,
+// и воркеры перестают брать новые задачи.
+func (rs *ResultSender) spoolResult(d ResultDestination, result models.JobResult) error {
+	data, err := json.Marshal(result)
 	if err != nil {
-		slog.Error("Failed to send result via gRPC",
-			slog.Int64("job_id", result.JobID),
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	logger := joblog.ForResult(rs.ctx, result).With(slog.String("sink", d.Sink.Name()))
+	for warned := false; ; warned = true {
+		err := d.Spool.Append(data)
+		if err == nil {
+			resultDeliveries.WithLabelValues(d.Sink.Name(), deliverySpooled).Inc()
+			logger.Info("Result saved to spool for later delivery")
+			return nil
+		}
+		if !errors.Is(err, spool.ErrFull) {
+			logger.Error("Failed to save result to spool", slog.String("error", err.Error()))
+			return err
+		}
+		if !warned {
+			logger.Warn("Results spool is full, waiting for delivery")
+		}
+
+		select {
+		case <-rs.ctx.Done():
+			logger.Error("Result lost: results spool is full")
+			return err
+		case <-time.After(spoolFullRetryInterval):
+		}
+	}
+}
+
+// replay досылает результаты из spool получателя, пока не начата остановка.
+func (rs *ResultSender) replay(d ResultDestination) {
+	defer rs.wg.Done()
+
+	ticker := time.NewTicker(spoolReplayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rs.flush:
+			return
+		case <-rs.ctx.Done():
+			return
+		case <-ticker.C:
+			rs.replaySpool(d)
+		}
+	}
+}
+
+// replaySpool отправляет результаты из spool по порядку, пока получатель доступен.
+// Результат удаляется из spool после успешной отправки или если получатель
+// его отклонил: иначе отклоненная запись навсегда остановила бы досылку.
+func (rs *ResultSender) replaySpool(d ResultDestination) {
+	replayed := 0
+	defer func() {
+		if replayed > 0 {
+			slog.Info("Spooled results delivered",
+				slog.String("sink", d.Sink.Name()),
+				slog.Int("results", replayed),
+				slog.Int("remaining", d.Spool.Len()),
+			)
+		}
+	}()
+
+	for d.Sink.Healthy() {
+		select {
+		case <-rs.flush:
+			return
+		default:
+		}
+
+		data, err := d.Spool.Next()
+		if errors.Is(err, spool.ErrEmpty) {
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to read results spool", slog.String("sink", d.Sink.Name()), "error", err)
+			return
+		}
+
+		var result models.JobResult
+		delivered := false
+		if err := json.Unmarshal(data, &result); err != nil {
+			slog.Error("Dropping undecodable result from spool", slog.String("sink", d.Sink.Name()), "error", err)
+		} else if err := rs.send(d.Sink, result); err != nil {
+			if rs.retryable(err) {
+				return
+			}
+			resultDeliveries.WithLabelValues(d.Sink.Name(), deliveryLost).Inc()
+			joblog.ForResult(rs.ctx, result).Error("Dropping result rejected by sink from spool",
+				slog.String("sink", d.Sink.Name()),
+				slog.String("error", err.Error()),
+			)
+		} else {
+			delivered = true
+		}
+
+		if err := d.Spool.Ack(); err != nil {
+			slog.Error("Failed to acknowledge spooled result", slog.String("sink", d.Sink.Name()), "error", err)
+			return
+		}
+		if delivered {
+			replayed++
+		}
+	}
+}
+
+// send доставляет результат одному получателю и учитывает исход в метриках.
+func (rs *ResultSender) send(sink ResultSink, result models.JobResult) error {
+	// Отправка результата — часть трассы задачи; trace context уходит получателю
+	ctx, span := tracing.Tracer().Start(tracing.Extract(rs.ctx, result.TraceHeaders), "job.deliver",
+		trace.WithAttributes(
+			tracing.AttrJobID.Int64(result.JobID),
+			attribute.String("job.status", string(result.Status)),
+			attribute.String("result.sink", sink.Name()),
+		),
+	)
+	defer span.End()
+	logger := joblog.ForResult(ctx, result).With(slog.String("sink", sink.Name()))
+
+	if err := sink.Send(ctx, result); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		resultDeliveries.WithLabelValues(sink.Name(), deliveryFailed).Inc()
+		logger.Error("Failed to send result", slog.String("error", err.Error()))
+		return err
+	}
+
+	resultDeliveries.WithLabelValues(sink.Name(), deliveryDelivered).Inc()
+	logger.Debug("Result sent successfully")
+	return nil
+}
+
+func (rs *ResultSender) sendProgress(p models.JobProgress) {
+	if err := rs.progressSink.HandleProgress(p); err != nil {
+		// Прогресс best-effort: итоговый статус все равно придет отдельно
+		slog.Debug("Failed to send progress via gRPC",
+			slog.Int64("job_id", p.JobID),
 			slog.String("error", err.Error()),
 		)
-		// TODO: отправить в Dead Letter Queue
-	} else {
-		slog.Debug("Result sent successfully", slog.Int64("job_id", result.JobID))
 	}
 }
@@ -0,0 +1,188 @@
+package worker_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/spool"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/worker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeSink struct {
+	name string
+	err  error
+
+	mu   sync.Mutex
+	sent []int64
+}
+
+func (s *fakeSink) Name() string  { return s.name }
+func (s *fakeSink) Healthy() bool { return false }
+func (s *fakeSink) Send(_ context.Context, result models.JobResult) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, result.JobID)
+	return nil
+}
+
+type noProgress struct{}
+
+func (noProgress) HandleProgress(models.JobProgress) error { return nil }
+
+func TestResultSenderFansOutAndSpoolsPerSink(t *testing.T) {
+	sp, err := spool.Open("test", t.TempDir(), 1<<20, 1<<10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sp.Close()
+
+	healthy := &fakeSink{name: "grpc"}
+	broken := &fakeSink{name: "kafka", err: status.Error(codes.Unavailable, "broker unavailable")}
+	results := make(chan models.JobResult, 1)
+
+	rs := worker.NewResultSender([]worker.ResultDestination{
+		{Sink: healthy},
+		{Sink: broken, Spool: sp},
+	}, noProgress{}, results, nil, context.Background())
+	rs.Start()
+
+	results <- models.JobResult{JobID: 1, Status: models.StatusCompleted}
+	if lost := rs.Stop(context.Background()); len(lost) != 0 {
+		t.Fatalf("expected no lost results, got %+v", lost)
+	}
+
+	if len(healthy.sent) != 1 || healthy.sent[0] != 1 {
+		t.Fatalf("expected result delivered to healthy sink, got %v", healthy.sent)
+	}
+	if sp.Len() != 1 {
+		t.Fatalf("expected result spooled for broken sink, got %d records", sp.Len())
+	}
+}
+
+// blockingProgress не отвечает, пока не закрыт release: управляющий сервис недоступен.
+type blockingProgress struct{ release chan struct{} }
+
+func (p blockingProgress) HandleProgress(models.JobProgress) error {
+	<-p.release
+	return nil
+}
+
+func TestResultSenderProgressDoesNotDelayResults(t *testing.T) {
+	sink := &fakeSink{name: "grpc"}
+	results := make(chan models.JobResult, 1)
+	progress := make(chan models.JobProgress, 1)
+	release := make(chan struct{})
+
+	rs := worker.NewResultSender([]worker.ResultDestination{{Sink: sink}},
+		blockingProgress{release: release}, results, progress, context.Background())
+	rs.Start()
+
+	progress <- models.JobProgress{JobID: 1, Percent: 50}
+	results <- models.JobResult{JobID: 1, Status: models.StatusCompleted}
+
+	deadline := time.After(time.Second)
+	for {
+		sink.mu.Lock()
+		sent := len(sink.sent)
+		sink.mu.Unlock()
+		if sent == 1 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("result was not delivered while progress was blocked")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	close(release)
+	rs.Stop(context.Background())
+}
+
+// rejectingSink недоступен, пока down, и всегда отклоняет результат задачи reject.
+type rejectingSink struct {
+	reject int64
+	down   atomic.Bool
+
+	mu   sync.Mutex
+	sent []int64
+}
+
+func (s *rejectingSink) Name() string  { return "grpc" }
+func (s *rejectingSink) Healthy() bool { return !s.down.Load() }
+func (s *rejectingSink) Send(_ context.Context, result models.JobResult) error {
+	if s.down.Load() {
+		return status.Error(codes.Unavailable, "connection refused")
+	}
+	if result.JobID == s.reject {
+		return status.Error(codes.InvalidArgument, "unknown job")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, result.JobID)
+	return nil
+}
+
+func (s *rejectingSink) delivered() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.sent)
+}
+
+func TestResultSenderDropsRejectedResultFromSpool(t *testing.T) {
+	sp, err := spool.Open("test", t.TempDir(), 1<<20, 1<<10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sp.Close()
+
+	sink := &rejectingSink{reject: 2}
+	sink.down.Store(true)
+	results := make(chan models.JobResult)
+	rs := worker.NewResultSender([]worker.ResultDestination{{Sink: sink, Spool: sp}}, noProgress{}, results, nil, context.Background())
+	rs.Start()
+	defer rs.Stop(context.Background())
+
+	// Получатель недоступен: все результаты сохраняются в spool
+	for id := int64(1); id <= 3; id++ {
+		results <- models.JobResult{JobID: id, Status: models.StatusCompleted}
+	}
+	waitFor(t, func() bool { return sp.Len() == 3 })
+
+	// Отклоненный результат не останавливает досылку остальных
+	sink.down.Store(false)
+	waitFor(t, func() bool { return sp.Len() == 0 })
+	if got := sink.delivered(); !slices.Equal(got, []int64{1, 3}) {
+		t.Fatalf("expected results 1 and 3 delivered from spool, got %v", got)
+	}
+
+	// Отклоненный результат не сохраняется в spool
+	results <- models.JobResult{JobID: 2, Status: models.StatusCompleted}
+	results <- models.JobResult{JobID: 4, Status: models.StatusCompleted}
+	waitFor(t, func() bool { return len(sink.delivered()) == 3 })
+	if sp.Len() != 0 {
+		t.Errorf("rejected result must not be spooled, got %d records", sp.Len())
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for !cond() {
+		select {
+		case <-deadline:
+			t.Fatal("condition not met in time")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
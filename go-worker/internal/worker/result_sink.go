@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/breaker"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/spool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResultSink — получатель результатов задач: управляющий сервис (gRPC),
+// топик Kafka и т. п. ResultSender доставляет каждый результат во все
+// получатели независимо друг от друга.
+type ResultSink interface {
+	// Name — имя получателя для логов, метрик и каталога spool.
+	Name() string
+	// Send доставляет итоговый результат задачи (COMPLETED или FAILED).
+	Send(ctx context.Context, result models.JobResult) error
+	// Healthy сообщает, стоит ли сейчас досылать результаты из spool.
+	Healthy() bool
+}
+
+// ResultDestination — получатель результатов и его spool (nil — отключен).
+type ResultDestination struct {
+	Sink  ResultSink
+	Spool *spool.Spool
+}
+
+// Исход доставки результата получателю.
+const (
+	deliveryDelivered = "delivered" // Отправлен сразу или из spool
+	deliveryFailed    = "failed"    // Попытка отправки не удалась
+	deliverySpooled   = "spooled"   // Сохранен в spool
+	deliveryLost      = "lost"      // Не отправлен и не сохранен или отклонен получателем
+)
+
+var resultDeliveries = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "result",
+	Name:      "deliveries_total",
+	Help:      "Result delivery outcomes by sink: delivered, failed, spooled or lost.",
+}, []string{"sink", "outcome"})
+
+// transient сообщает, что ошибка отправки связана с доступностью получателя
+// и результат стоит сохранить в spool и дослать позже: breaker разомкнут,
+// истек таймаут, транспортная ошибка, gRPC Unavailable, DeadlineExceeded или
+// ResourceExhausted (те же коды размыкают breaker gRPC), временная ошибка Kafka. Остальные ошибки означают, что получатель отклонил
+// результат, и повтор той же записи получит тот же отказ.
+func transient(err error) bool {
+	if errors.Is(err, breaker.ErrOpen) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		for _, e := range writeErrs {
+			if e != nil && transient(e) {
+				return true
+			}
+		}
+		return false
+	}
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		return kafkaErr.Temporary()
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
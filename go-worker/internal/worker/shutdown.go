@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// ErrShutdown — причина отмены задач, не завершившихся до дедлайна остановки.
+// Такие задачи отправляются как временная ошибка и повторяются другим воркером.
+var ErrShutdown = errors.New("worker shutting down")
+
+// interruptGracePeriod — сколько Stop ждет executor'ы после отмены их контекста.
+const interruptGracePeriod = time.Second
+
+// StopReport — что пул не успел выполнить до дедлайна остановки.
+type StopReport struct {
+	Interrupted []JobInfo    // Прерваны на дедлайне, результат — временная ошибка
+	Stuck       []JobInfo    // Из Interrupted: executor не завершился после отмены, результата не будет
+	Abandoned   []models.Job // Получены из Kafka, но не запущены
+}
+
+// Stop ждет, пока воркеры выполнят задачи из канала (он должен быть закрыт)
+// до дедлайна ctx. После дедлайна выполняемые задачи отменяются с ErrShutdown,
+// а оставшиеся в канале и очередях топиков не запускаются.
+func (wp *WorkerPool) Stop(ctx context.Context) StopReport {
+	var report StopReport
+
+	done := make(chan struct{})
+	go func() {
+		wp.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		report.Interrupted = wp.InFlightJobs()
+		slog.Warn("Execution deadline exceeded, interrupting jobs", slog.Int("jobs", len(report.Interrupted)))
+		wp.cancel(ErrShutdown)
+
+		select {
+		case <-done:
+		case <-time.After(interruptGracePeriod):
+			report.Stuck = wp.InFlightJobs()
+			close(wp.abandon)
+		}
+	}
+
+	report.Abandoned = append(wp.takeAbandoned(), wp.topics.drain()...)
+	report.Abandoned = append(report.Abandoned, wp.drainQueue()...)
+
+	wp.cancel(ErrShutdown)
+	close(wp.stopChan)
+	wp.bgWg.Wait()
+
+	return report
+}
+
+func (wp *WorkerPool) addAbandoned(job models.Job) {
+	wp.abandonedMu.Lock()
+	defer wp.abandonedMu.Unlock()
+
+	wp.abandoned = append(wp.abandoned, job)
+}
+
+func (wp *WorkerPool) takeAbandoned() []models.Job {
+	wp.abandonedMu.Lock()
+	defer wp.abandonedMu.Unlock()
+
+	res := wp.abandoned
+	wp.abandoned = nil
+	return res
+}
+
+// drainQueue забирает задачи, оставшиеся в канале, не блокируясь.
+func (wp *WorkerPool) drainQueue() []models.Job {
+	var res []models.Job
+	for {
+		select {
+		case job, ok := <-wp.jobsChan:
+			if !ok {
+				return res
+			}
+			res = append(res, job)
+		default:
+			return res
+		}
+	}
+}
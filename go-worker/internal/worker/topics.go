@@ -0,0 +1,145 @@
+package worker
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// topicLimit — ограничения для задач из одного топика (секция topics).
+type topicLimit struct {
+	concurrency int // 0 — без ограничения числа задач
+	jobTypes    []models.JobType
+	space       chan struct{} // Места в очереди топика; nil без ограничения
+
+	mu      sync.Mutex
+	running int
+	queue   []models.Job
+}
+
+// topicLimits применяет к задачам настройки их топика Kafka.
+// Задачи без секции топика (и задачи по расписанию) не ограничиваются.
+//
+// Задача топика без свободного слота не занимает воркер: она ждет в очереди
+// топика, а воркер берет следующую задачу из канала. Освободивший слот воркер
+// сразу запускает задачу из очереди топика. Воркер ждет, только если очередь
+// топика заполнена (ее размер равен буферу канала задач).
+type topicLimits map[string]*topicLimit
+
+func newTopicLimits(topics map[string]config.TopicConfig, queueSize int) topicLimits {
+	queueSize = max(queueSize, 1)
+	limits := make(topicLimits, len(topics))
+	for name, tc := range topics {
+		l := &topicLimit{concurrency: tc.Concurrency}
+		if tc.Concurrency > 0 {
+			l.space = make(chan struct{}, queueSize)
+		}
+		for _, t := range tc.JobTypes {
+			l.jobTypes = append(l.jobTypes, models.JobType(t))
+		}
+		limits[name] = l
+	}
+	return limits
+}
+
+// allowed сообщает, что тип задачи разрешен в ее топике.
+func (tl topicLimits) allowed(job models.Job) bool {
+	l := tl[job.Topic]
+	return l == nil || len(l.jobTypes) == 0 || slices.Contains(l.jobTypes, job.Type)
+}
+
+// limited возвращает ограничение числа задач топика или nil.
+func (tl topicLimits) limited(job models.Job) *topicLimit {
+	l := tl[job.Topic]
+	if l == nil || l.concurrency == 0 {
+		return nil
+	}
+	return l
+}
+
+// admit занимает слот топика для задачи. Если слотов нет, задача ставится
+// в очередь топика и admit возвращает false: ее запустит воркер, который
+// освободит слот (см. release).
+func (tl topicLimits) admit(ctx context.Context, job models.Job) (bool, error) {
+	l := tl.limited(job)
+	if l == nil {
+		return true, nil
+	}
+
+	l.mu.Lock()
+	if l.running < l.concurrency {
+		l.running++
+		l.mu.Unlock()
+		return true, nil
+	}
+	l.mu.Unlock()
+
+	select {
+	case l.space <- struct{}{}:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Слот мог освободиться, пока задача ждала места в очереди
+	if l.running < l.concurrency {
+		<-l.space
+		l.running++
+		return true, nil
+	}
+	l.queue = append(l.queue, job)
+	topicJobsQueued.WithLabelValues(job.Topic).Inc()
+	return false, nil
+}
+
+// release освобождает слот выполненной задачи. Если в очереди топика есть
+// задача, слот переходит к ней и она возвращается для запуска.
+func (tl topicLimits) release(job models.Job) (models.Job, bool) {
+	l := tl.limited(job)
+	if l == nil {
+		return models.Job{}, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.queue) == 0 {
+		l.running--
+		return models.Job{}, false
+	}
+	next := l.queue[0]
+	l.queue = l.queue[1:]
+	<-l.space
+	topicJobsQueued.WithLabelValues(next.Topic).Dec()
+	return next, true
+}
+
+// queued возвращает число задач в очередях топиков.
+func (tl topicLimits) queued() int {
+	n := 0
+	for _, l := range tl {
+		l.mu.Lock()
+		n += len(l.queue)
+		l.mu.Unlock()
+	}
+	return n
+}
+
+// drain забирает задачи из очередей топиков (см. Stop).
+func (tl topicLimits) drain() []models.Job {
+	var res []models.Job
+	for name, l := range tl {
+		l.mu.Lock()
+		for range l.queue {
+			<-l.space
+		}
+		res = append(res, l.queue...)
+		topicJobsQueued.WithLabelValues(name).Sub(float64(len(l.queue)))
+		l.queue = nil
+		l.mu.Unlock()
+	}
+	return res
+}
@@ -0,0 +1,86 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/middleware"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/worker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanChainFromReceiveToDeliver(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  5 * time.Second,
+		},
+	}
+	jobs := make(chan models.Job, 1)
+	results := make(chan models.JobResult, 1)
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: middleware.Tracing()(models.JobTypeSleep, func(context.Context, string) (any, error) {
+			return "ok", nil
+		}),
+	}
+	sink := &fakeSink{name: "grpc"}
+
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, nil, nil, nil, context.Background())
+	rs := worker.NewResultSender([]worker.ResultDestination{{Sink: sink}}, noProgress{}, results, nil, context.Background())
+	wp.Start()
+	rs.Start()
+
+	// Так задачу передает consumer: контекст спана job.receive едет в заголовках задачи
+	spanCtx, receive := tracing.Tracer().Start(context.Background(), "job.receive")
+	jobs <- models.Job{
+		ID:           1,
+		Type:         models.JobTypeSleep,
+		TraceHeaders: tracing.Inject(spanCtx),
+		ReceivedAt:   time.Now(),
+	}
+	receive.End()
+
+	close(jobs)
+	wp.Stop(context.Background())
+	rs.Stop(context.Background())
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = s
+	}
+	root := spans["job.receive"]
+	if root == nil {
+		t.Fatal("job.receive span not recorded")
+	}
+	for _, name := range []string{"job.queue_wait", "job.execute SLEEP", "job.deliver"} {
+		s := spans[name]
+		if s == nil {
+			t.Errorf("%s span not recorded, got %v", name, recorder.Ended())
+			continue
+		}
+		if s.SpanContext().TraceID() != root.SpanContext().TraceID() {
+			t.Errorf("%s is in another trace", name)
+		}
+		if s.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("%s parent is %s, want job.receive %s", name, s.Parent().SpanID(), root.SpanContext().SpanID())
+		}
+	}
+}
@@ -2,115 +2,429 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/dedup"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/joblog"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/lease"
 	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/progress"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type WorkerPool struct {
-	numWorkers  int
-	jobTimeout  time.Duration
-	jobsChan    <-chan models.Job
-	resultsChan chan<- models.JobResult
-	executors   map[models.JobType]jobregistry.Executor
-
-	wg  sync.WaitGroup
-	ctx context.Context
+	numWorkers        int
+	jobTimeout        atomic.Int64 // time.Duration, меняется при hot reload
+	progressInterval  time.Duration
+	heartbeatInterval time.Duration
+	jobsChan          <-chan models.Job
+	resultsChan       chan<- models.JobResult
+	progressChan      chan<- models.JobProgress
+	executorsMu       sync.RWMutex
+	executors         map[models.JobType]jobregistry.Executor
+	heartbeater       lease.Heartbeater
+	dedup             *dedup.Guard
+	workflows         Workflows
+	topics            topicLimits
+	inFlight          *inFlight
+	logCapture        string // JOB_LOG_CAPTURE: none | failed | all
+	logCaptureLimit   int
+	workerID          string // WORKER_ID, поле worker_id в логах задач
+
+	// Задачи, взятые из канала, но еще не переданные в канал результатов
+	busy atomic.Int64
+
+	// Канал остановки каждого запущенного воркера; длина — текущий размер пула
+	workersMu sync.Mutex
+	workers   []chan struct{}
+	nextSlot  int
+
+	// Задачи, взятые из канала после отмены ctx (см. Stop)
+	abandonedMu sync.Mutex
+	abandoned   []models.Job
+
+	wg       sync.WaitGroup
+	bgWg     sync.WaitGroup
+	stopChan chan struct{}
+	abandon  chan struct{} // Закрывается, если Stop не дождался воркеров
+	ctx      context.Context
+	cancel   context.CancelCauseFunc
 }
 
 func NewWorkerPool(
 	cfg *config.Config,
 	jobsChan <-chan models.Job,
 	resultsChan chan<- models.JobResult,
+	progressChan chan<- models.JobProgress,
 	executors map[models.JobType]jobregistry.Executor,
+	heartbeater lease.Heartbeater,
+	dedupGuard *dedup.Guard,
+	workflows Workflows,
 	ctx context.Context,
 ) *WorkerPool {
-	return &WorkerPool{
-		numWorkers:  cfg.WorkerPoolSize,
-		jobTimeout:  cfg.MaxJobTimeout,
-		jobsChan:    jobsChan,
-		resultsChan: resultsChan,
-		executors:   executors,
-		wg:          sync.WaitGroup{},
-		ctx:         ctx,
+	wp := &WorkerPool{
+		numWorkers:        cfg.WorkerPoolSize,
+		progressInterval:  cfg.ProgressInterval,
+		heartbeatInterval: cfg.HeartbeatInterval,
+		jobsChan:          jobsChan,
+		resultsChan:       resultsChan,
+		progressChan:      progressChan,
+		executors:         executors,
+		heartbeater:       heartbeater,
+		dedup:             dedupGuard,
+		workflows:         workflows,
+		topics:            newTopicLimits(cfg.Topics, cap(jobsChan)),
+		inFlight:          newInFlight(),
+		logCapture:        cfg.JobLogCapture,
+		logCaptureLimit:   cfg.JobLogCaptureMaxBytes,
+		workerID:          cfg.WorkerID,
+		wg:                sync.WaitGroup{},
+		stopChan:          make(chan struct{}),
+		abandon:           make(chan struct{}),
 	}
+	wp.ctx, wp.cancel = context.WithCancelCause(ctx)
+	wp.jobTimeout.Store(int64(cfg.MaxJobTimeout))
+
+	return wp
 }
 
 func (wp *WorkerPool) Start() {
-	for i := range wp.numWorkers {
+	wp.Resize(wp.numWorkers)
+
+	if wp.heartbeater != nil && wp.heartbeatInterval > 0 {
+		wp.bgWg.Add(1)
+		go wp.runHeartbeats()
+	}
+}
+
+// AddExecutor добавляет executor типа задачи, появившегося после старта пула
+// (плагин, запустившийся не с первой попытки).
+func (wp *WorkerPool) AddExecutor(jobType models.JobType, exec jobregistry.Executor) {
+	wp.executorsMu.Lock()
+	defer wp.executorsMu.Unlock()
+	wp.executors[jobType] = exec
+}
+
+// Size возвращает число воркеров пула.
+func (wp *WorkerPool) Size() int {
+	wp.workersMu.Lock()
+	defer wp.workersMu.Unlock()
+
+	return len(wp.workers)
+}
+
+// Resize меняет число воркеров на лету. Лишние воркеры завершаются
+// после текущей задачи, выполняющиеся задачи не прерываются.
+func (wp *WorkerPool) Resize(n int) {
+	wp.workersMu.Lock()
+	defer wp.workersMu.Unlock()
+
+	for len(wp.workers) < n {
+		quit := make(chan struct{})
+		wp.workers = append(wp.workers, quit)
 		wp.wg.Add(1)
-		go wp.runWorker(i)
+		go wp.runWorker(wp.nextSlot, quit)
+		wp.nextSlot++
+	}
+	for len(wp.workers) > n {
+		last := len(wp.workers) - 1
+		close(wp.workers[last])
+		wp.workers = wp.workers[:last]
 	}
 }
 
-func (wp *WorkerPool) Stop() {
-	wp.wg.Wait()
+// Idle сообщает, что в канале и очередях топиков нет задач и ни одна задача
+// не выполняется и не ожидает передачи результата.
+func (wp *WorkerPool) Idle() bool {
+	return len(wp.jobsChan) == 0 && wp.topics.queued() == 0 && wp.busy.Load() == 0
 }
 
-func (wp *WorkerPool) runWorker(id int) {
+// SetJobTimeout меняет таймаут для задач, которые начнутся после вызова.
+func (wp *WorkerPool) SetJobTimeout(d time.Duration) {
+	wp.jobTimeout.Store(int64(d))
+}
+
+// Load возвращает число выполняемых задач и задач, ожидающих в канале
+// и очередях топиков.
+func (wp *WorkerPool) Load() (int, int) {
+	return wp.inFlight.count(), len(wp.jobsChan) + wp.topics.queued()
+}
+
+func (wp *WorkerPool) runWorker(slot int, quit <-chan struct{}) {
 	defer wp.wg.Done()
-	slog.Debug("Worker started", slog.Int("worker_id", id))
+	slog.Debug("Worker started", slog.Int("worker_slot", slot))
 
-	for job := range wp.jobsChan {
+	for {
+		var job models.Job
 		select {
+		case <-quit:
+			slog.Debug("Worker stopped by pool resize", slog.Int("worker_slot", slot))
+			return
 		case <-wp.ctx.Done():
 			return
-		default:
+		case j, ok := <-wp.jobsChan:
+			if !ok {
+				slog.Debug("Worker stopped", slog.Int("worker_slot", slot))
+				return
+			}
+			job = j
 		}
 
-		slog.Debug("Processing job",
-			slog.Int("worker_id", id),
-			slog.Int64("job_id", job.ID),
-			slog.String("type", string(job.Type)),
-		)
+		// Без свободного слота задача уходит в очередь топика, воркер берет следующую
+		run, err := wp.topics.admit(wp.ctx, job)
+		if err != nil {
+			wp.addAbandoned(job)
+			return
+		}
+		for run {
+			select {
+			case <-wp.ctx.Done():
+				wp.addAbandoned(job)
+				return
+			default:
+			}
 
-		result := wp.process(job)
+			wp.handle(slot, job)
+			job, run = wp.topics.release(job)
+		}
+	}
+}
 
-		select {
-		case wp.resultsChan <- result:
-		case <-wp.ctx.Done():
-			return
+// handle выполняет задачу и передает результат отправителю.
+func (wp *WorkerPool) handle(slot int, job models.Job) {
+	wp.busy.Add(1)
+	defer wp.busy.Add(-1)
+
+	// Логгер задачи с полями корреляции доступен executor'ам через контекст
+	ctx := tracing.Extract(wp.ctx, job.TraceHeaders)
+	logger := joblog.ForJob(ctx, job, wp.workerID, slot)
+	ctx = joblog.WithLogger(ctx, logger)
+	logger.Debug("Processing job")
+
+	if job.Topic != "" {
+		topicJobsRunning.WithLabelValues(job.Topic).Inc()
+	}
+	result, ok := wp.process(ctx, slot, job)
+	if job.Topic != "" {
+		topicJobsRunning.WithLabelValues(job.Topic).Dec()
+		if ok {
+			topicJobs.WithLabelValues(job.Topic, string(result.Status)).Inc()
 		}
 	}
+	if ok && wp.workflows != nil && (job.Type == models.JobTypeWorkflow || job.Workflow != nil) {
+		// Результат шага не отправляется: его получает workflow, а управляющему
+		// сервису уходит итог задачи WORKFLOW после последнего шага
+		result, ok = wp.workflows.Continue(ctx, job, result)
+	}
+	if !ok {
+		return
+	}
+	if job.Cron != "" {
+		// У задачи по расписанию нет записи в управляющем сервисе: итог только в логе
+		logger.Info("Scheduled job finished",
+			slog.String("status", string(result.Status)),
+			slog.String("error", result.Error),
+			slog.Duration("duration", result.Duration),
+		)
+		return
+	}
 
-	slog.Debug("Worker stopped", slog.Int("worker_id", id))
+	// Результаты задач, прерванных при остановке, тоже отправляются
+	select {
+	case wp.resultsChan <- result:
+	case <-wp.abandon:
+		logger.Warn("Result dropped: worker pool stopped without waiting for the job")
+	}
 }
 
-func (wp *WorkerPool) process(job models.Job) models.JobResult {
-	ctx, cancel := context.WithTimeout(wp.ctx, wp.jobTimeout)
+// process выполняет задачу с учетом дедупликации. Второе значение false означает,
+// что результат отправлять не нужно (дубль уже выполняется или аренда отозвана).
+func (wp *WorkerPool) process(ctx context.Context, slot int, job models.Job) (models.JobResult, bool) {
+	// Таймаут читается один раз: захват дедупликации должен пережить выполнение,
+	// даже если MAX_JOB_TIMEOUT увеличат во время выполнения
+	timeout := time.Duration(wp.jobTimeout.Load())
+	if wp.dedup == nil {
+		return wp.execute(ctx, slot, job, timeout)
+	}
+
+	logger := joblog.FromContext(ctx)
+	key := job.Key()
+	claim := wp.dedup.Begin(wp.ctx, key, timeout)
+
+	switch claim.Outcome {
+	case dedup.OutcomeCompleted:
+		logger.Info("Duplicate job already completed, re-sending cached result",
+			slog.String("owner", claim.Owner),
+		)
+		cached := claim.Result
+		cached.TraceHeaders = job.TraceHeaders
+		return cached, true
+	case dedup.OutcomeRunning:
+		logger.Info("Duplicate job already running, skipping",
+			slog.String("owner", claim.Owner),
+		)
+		return models.JobResult{}, false
+	case dedup.OutcomeAcquired:
+	}
+
+	result, ok := wp.execute(ctx, slot, job, timeout)
+	if !ok {
+		wp.dedup.Abort(key)
+		return result, false
+	}
+	wp.dedup.Finish(key, result)
+
+	return result, true
+}
+
+// execute выполняет задачу и дополняет результат типом задачи, временем выполнения,
+// номером попытки и, если включен JOB_LOG_CAPTURE, логом выполнения.
+// Второе значение false означает, что результат отправлять не нужно (аренда задачи отозвана).
+func (wp *WorkerPool) execute(ctx context.Context, slot int, job models.Job, timeout time.Duration) (models.JobResult, bool) {
+	if !job.ReceivedAt.IsZero() {
+		// Время между получением из Kafka и началом выполнения
+		_, span := tracing.Tracer().Start(ctx, "job.queue_wait",
+			trace.WithTimestamp(job.ReceivedAt),
+			trace.WithAttributes(tracing.AttrJobID.Int64(job.ID), tracing.AttrJobType.String(string(job.Type))),
+		)
+		span.End()
+	}
+
+	var capture *joblog.Capture
+	if wp.logCapture == "failed" || wp.logCapture == "all" {
+		capture = joblog.NewCapture(wp.logCaptureLimit)
+		ctx = joblog.WithLogger(ctx, capture.Tee(joblog.FromContext(ctx)))
+	}
+
+	start := time.Now()
+	result, ok := wp.run(ctx, slot, job, timeout)
+	result.Type = job.Type
+	result.Duration = time.Since(start)
+	result.Attempt = job.Attempt
+	result.TraceHeaders = job.TraceHeaders
+	if capture != nil && (wp.logCapture == "all" || result.Status == models.StatusFailed) {
+		result.Logs = capture.String()
+	}
+	return result, ok
+}
+
+// run вызывает executor задачи и кодирует его результат в JSON.
+func (wp *WorkerPool) run(ctx context.Context, slot int, job models.Job, timeout time.Duration) (models.JobResult, bool) {
+	ctx, cancelLease := context.WithCancelCause(ctx)
+	defer cancelLease(nil)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	wp.executorsMu.RLock()
 	exec, exists := wp.executors[job.Type]
+	wp.executorsMu.RUnlock()
 	if !exists {
 		return models.JobResult{
 			JobID:  job.ID,
 			Status: models.StatusFailed,
 			Error:  fmt.Sprintf("unknown job type: %s", job.Type),
-		}
+		}, true
+	}
+	if !wp.topics.allowed(job) {
+		topicJobsRejected.WithLabelValues(job.Topic, string(job.Type)).Inc()
+		return models.JobResult{
+			JobID:  job.ID,
+			Status: models.StatusFailed,
+			Error:  fmt.Sprintf("job type %s is not allowed in topic %s", job.Type, job.Topic),
+		}, true
+	}
+
+	wp.inFlight.add(&inFlightJob{
+		job:       job,
+		slot:      slot,
+		startedAt: time.Now(),
+		cancel:    cancelLease,
+	})
+	defer wp.inFlight.remove(job.Key())
+
+	logger := joblog.FromContext(ctx)
+	if job.Cron == "" {
+		// Прогресс задачи по расписанию некуда отправлять: управляющий сервис о ней не знает
+		ctx = progress.WithReporter(ctx, wp.newProgressReporter(logger, job.ID))
 	}
 
 	output, err := exec(ctx, job.Payload)
 
+	switch cause := context.Cause(ctx); {
+	case errors.Is(cause, ErrLeaseRevoked):
+		logger.Warn("Job abandoned after lease revocation")
+		return models.JobResult{}, false
+	case errors.Is(cause, ErrShutdown):
+		// Задача не завершилась до дедлайна остановки: ее повторит другой воркер
+		logger.Warn("Job interrupted by shutdown")
+		return models.JobResult{
+			JobID:     job.ID,
+			Status:    models.StatusFailed,
+			Error:     ErrShutdown.Error(),
+			Retryable: true,
+		}, true
+	case errors.Is(cause, ErrJobCancelled):
+		logger.Warn("Job cancelled by operator")
+		return models.JobResult{
+			JobID:  job.ID,
+			Status: models.StatusFailed,
+			Error:  ErrJobCancelled.Error(),
+		}, true
+	}
+
 	if err != nil {
-		slog.Error("Job failed",
-			slog.Int64("job_id", job.ID),
-			slog.String("error", err.Error()),
-		)
+		logger.Error("Job failed", slog.String("error", err.Error()))
+		return models.JobResult{
+			JobID:     job.ID,
+			Status:    models.StatusFailed,
+			Error:     err.Error(),
+			Retryable: models.IsRetryable(err),
+		}, true
+	}
+
+	// Результат кодируется один раз; дальше он передается как готовый JSON
+	data, err := json.Marshal(output)
+	if err != nil {
+		logger.Error("Failed to encode job result", slog.String("error", err.Error()))
 		return models.JobResult{
 			JobID:  job.ID,
 			Status: models.StatusFailed,
-			Error:  err.Error(),
-		}
+			Error:  fmt.Sprintf("failed to encode result: %v", err),
+		}, true
 	}
 
 	return models.JobResult{
 		JobID:  job.ID,
 		Status: models.StatusCompleted,
-		Result: output,
-	}
+		Result: data,
+	}, true
+}
+
+// newProgressReporter создает Reporter, который с ограничением частоты
+// пересылает прогресс задачи в канал прогресса. Если канал переполнен,
+// обновление отбрасывается: прогресс не должен тормозить выполнение.
+func (wp *WorkerPool) newProgressReporter(logger *slog.Logger, jobID int64) progress.Reporter {
+	return progress.NewThrottled(wp.progressInterval, func(u progress.Update) {
+		p := models.JobProgress{
+			JobID:      jobID,
+			Percent:    u.Percent,
+			Stage:      u.Stage,
+			ETA:        u.ETA,
+			ReportedAt: time.Now(),
+		}
+		select {
+		case wp.progressChan <- p:
+		default:
+			logger.Debug("Progress update dropped")
+		}
+	})
 }
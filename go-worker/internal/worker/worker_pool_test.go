@@ -1,7 +1,491 @@
 package worker_test
 
-import "testing"
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/dedup"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/lease"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/progress"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/worker"
+)
 
 func TestPlaceholder(t *testing.T) {
 	// TODO: implement tests
 }
+
+type revokingHeartbeater struct{}
+
+func (revokingHeartbeater) Heartbeat(context.Context, int64, int, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func TestLeaseRevocationCancelsJob(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  5 * time.Second,
+		},
+		HeartbeatInterval: 10 * time.Millisecond,
+	}
+	jobs := make(chan models.Job, 1)
+	results := make(chan models.JobResult, 1)
+	cancelled := make(chan struct{})
+
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(ctx context.Context, _ string) (any, error) {
+			<-ctx.Done()
+			close(cancelled)
+			return nil, ctx.Err()
+		},
+	}
+
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, revokingHeartbeater{}, nil, nil, context.Background())
+	wp.Start()
+
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("job was not cancelled after lease revocation")
+	}
+
+	close(jobs)
+	wp.Stop(context.Background())
+
+	select {
+	case r := <-results:
+		t.Fatalf("expected no result for revoked job, got %+v", r)
+	default:
+	}
+}
+
+// unsupportedHeartbeater — сервер без RPC Heartbeat.
+type unsupportedHeartbeater struct{ calls atomic.Int32 }
+
+func (h *unsupportedHeartbeater) Heartbeat(context.Context, int64, int, time.Duration) (bool, error) {
+	h.calls.Add(1)
+	return false, lease.ErrHeartbeatsUnsupported
+}
+
+func TestHeartbeatsStopWhenUnsupported(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  5 * time.Second,
+		},
+		HeartbeatInterval: 5 * time.Millisecond,
+	}
+	jobs := make(chan models.Job, 1)
+	results := make(chan models.JobResult, 1)
+	hb := &unsupportedHeartbeater{}
+
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(context.Context, string) (any, error) {
+			time.Sleep(100 * time.Millisecond)
+			return &models.SleepResult{SleptMs: 100}, nil
+		},
+	}
+
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, hb, nil, nil, context.Background())
+	wp.Start()
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep}
+	close(jobs)
+	wp.Stop(context.Background())
+
+	if r := <-results; r.Status != models.StatusCompleted {
+		t.Fatalf("expected job to complete without heartbeats, got %+v", r)
+	}
+	if n := hb.calls.Load(); n != 1 {
+		t.Fatalf("expected a single heartbeat attempt, got %d", n)
+	}
+}
+
+func TestScheduledJobHasNoLeaseOrProgress(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  5 * time.Second,
+		},
+		HeartbeatInterval: 5 * time.Millisecond,
+		ProgressInterval:  time.Millisecond,
+	}
+	jobs := make(chan models.Job, 1)
+	results := make(chan models.JobResult, 1)
+	progressChan := make(chan models.JobProgress, 10)
+	hb := &unsupportedHeartbeater{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(ctx context.Context, _ string) (any, error) {
+			progress.Report(ctx, progress.Update{Percent: 50})
+			close(started)
+			select {
+			case <-release:
+				return &models.SleepResult{}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	}
+
+	wp := worker.NewWorkerPool(cfg, jobs, results, progressChan, executors, hb, nil, nil, context.Background())
+	wp.Start()
+	jobs <- models.Job{Type: models.JobTypeSleep, Cron: "nightly", CreatedAt: 1}
+	<-started
+
+	time.Sleep(50 * time.Millisecond)
+	if wp.Cancel(0) {
+		t.Error("scheduled job must not be cancelled by job ID 0")
+	}
+	close(release)
+	close(jobs)
+	wp.Stop(context.Background())
+
+	if n := hb.calls.Load(); n != 0 {
+		t.Errorf("expected no heartbeats for scheduled job, got %d", n)
+	}
+	if len(progressChan) != 0 {
+		t.Errorf("expected no progress for scheduled job, got %d updates", len(progressChan))
+	}
+	if len(results) != 0 {
+		t.Error("scheduled job result must not be sent")
+	}
+}
+
+func TestResultEncodedOnce(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  5 * time.Second,
+		},
+	}
+	jobs := make(chan models.Job, 1)
+	results := make(chan models.JobResult, 1)
+
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(context.Context, string) (any, error) {
+			return &models.SleepResult{SleptMs: 10}, nil
+		},
+	}
+
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, nil, nil, nil, context.Background())
+	wp.Start()
+
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep, Attempt: 2}
+	close(jobs)
+	wp.Stop(context.Background())
+
+	r := <-results
+	if r.Status != models.StatusCompleted || string(r.Result) != `{"slept_ms":10}` {
+		t.Fatalf("expected structured result, got %+v", r)
+	}
+	if r.Attempt != 2 {
+		t.Errorf("expected attempt 2, got %d", r.Attempt)
+	}
+}
+
+func TestDuplicateJobResendsCachedResult(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  5 * time.Second,
+		},
+		WorkerID: "worker-a",
+		DedupTTL: time.Minute,
+	}
+	jobs := make(chan models.Job, 2)
+	results := make(chan models.JobResult, 2)
+
+	var calls atomic.Int32
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(context.Context, string) (any, error) {
+			calls.Add(1)
+			return &models.SleepResult{SleptMs: 10}, nil
+		},
+	}
+
+	guard := dedup.NewGuard(cfg, dedup.NewMemoryStore(10))
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, nil, guard, nil, context.Background())
+	wp.Start()
+
+	// Повторная доставка той же задачи, например после ребалансировки Kafka
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep}
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep}
+	close(jobs)
+	wp.Stop(context.Background())
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected job to be executed once, got %d", n)
+	}
+	for range 2 {
+		if r := <-results; r.JobID != 1 || r.Status != models.StatusCompleted || string(r.Result) != `{"slept_ms":10}` {
+			t.Fatalf("expected cached result for duplicate, got %+v", r)
+		}
+	}
+}
+
+func TestNewAttemptBypassesDedup(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  5 * time.Second,
+		},
+		WorkerID: "worker-a",
+		DedupTTL: time.Minute,
+	}
+	jobs := make(chan models.Job, 2)
+	results := make(chan models.JobResult, 2)
+
+	var calls atomic.Int32
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(context.Context, string) (any, error) {
+			calls.Add(1)
+			return &models.SleepResult{SleptMs: 10}, nil
+		},
+	}
+
+	// Попытка 1 задачи 1 числится выполняемой упавшим воркером, задачи 2 — выполненной
+	store := dedup.NewMemoryStore(10)
+	ctx := context.Background()
+	for _, id := range []int64{1, 2} {
+		key := models.Job{ID: id, Attempt: 1}.Key()
+		if _, err := store.Claim(ctx, key, "worker-dead", time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stale := models.JobResult{JobID: 2, Status: models.StatusCompleted, Attempt: 1}
+	if err := store.Complete(ctx, models.Job{ID: 2, Attempt: 1}.Key(), "worker-dead", stale, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, nil, dedup.NewGuard(cfg, store), nil, ctx)
+	wp.Start()
+
+	// Управляющий сервис переназначил задачи с новой попыткой
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep, Attempt: 2}
+	jobs <- models.Job{ID: 2, Type: models.JobTypeSleep, Attempt: 2}
+	close(jobs)
+	wp.Stop(context.Background())
+	close(results)
+
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected both reassigned jobs executed, got %d", n)
+	}
+	for r := range results {
+		if r.Status != models.StatusCompleted || r.Attempt != 2 {
+			t.Errorf("expected result of attempt 2, got %+v", r)
+		}
+	}
+}
+
+// ttlStore запоминает время жизни захватов.
+type ttlStore struct {
+	dedup.Store
+	ttl atomic.Int64
+}
+
+func (s *ttlStore) Claim(ctx context.Context, key, owner string, ttl time.Duration) (dedup.Claim, error) {
+	s.ttl.Store(int64(ttl))
+	return s.Store.Claim(ctx, key, owner, ttl)
+}
+
+func TestDedupClaimOutlivesReloadedTimeout(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  time.Second,
+		},
+		WorkerID: "worker-a",
+		DedupTTL: time.Minute,
+	}
+	jobs := make(chan models.Job, 1)
+	results := make(chan models.JobResult, 1)
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(context.Context, string) (any, error) { return nil, nil },
+	}
+
+	store := &ttlStore{Store: dedup.NewMemoryStore(10)}
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, nil, dedup.NewGuard(cfg, store), nil, context.Background())
+	// MAX_JOB_TIMEOUT увеличен hot reload'ом после создания пула
+	wp.SetJobTimeout(time.Hour)
+	wp.Start()
+
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep}
+	close(jobs)
+	wp.Stop(context.Background())
+
+	if ttl := time.Duration(store.ttl.Load()); ttl <= time.Hour {
+		t.Errorf("claim TTL %s must exceed reloaded job timeout", ttl)
+	}
+}
+
+func TestStopDeadlineInterruptsJob(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 1,
+			MaxJobTimeout:  time.Minute,
+		},
+	}
+	jobs := make(chan models.Job, 2)
+	results := make(chan models.JobResult, 1)
+	started := make(chan struct{})
+
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(ctx context.Context, _ string) (any, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, nil, nil, nil, context.Background())
+	wp.Start()
+
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep}
+	<-started
+	jobs <- models.Job{ID: 2, Type: models.JobTypeSleep}
+	close(jobs)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	report := wp.Stop(ctx)
+
+	if len(report.Interrupted) != 1 || report.Interrupted[0].JobID != 1 || len(report.Stuck) != 0 {
+		t.Fatalf("expected job 1 to be interrupted, got %+v", report)
+	}
+	if len(report.Abandoned) != 1 || report.Abandoned[0].ID != 2 {
+		t.Fatalf("expected job 2 to be abandoned, got %+v", report.Abandoned)
+	}
+
+	r := <-results
+	if r.JobID != 1 || r.Status != models.StatusFailed || !r.Retryable {
+		t.Fatalf("expected retryable failure for interrupted job, got %+v", r)
+	}
+}
+
+func TestTopicOverridesLimitJobs(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 3,
+			MaxJobTimeout:  5 * time.Second,
+		},
+		Topics: map[string]config.TopicConfig{
+			"jobs.tenant-a": {Concurrency: 1, JobTypes: []string{string(models.JobTypeSleep)}},
+		},
+	}
+	jobs := make(chan models.Job, 4)
+	results := make(chan models.JobResult, 4)
+
+	var running, maxRunning atomic.Int32
+	sleep := func(context.Context, string) (any, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		if n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		time.Sleep(20 * time.Millisecond)
+		return &models.SleepResult{SleptMs: 20}, nil
+	}
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep:       sleep,
+		models.JobTypeImageResize: sleep,
+	}
+
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, nil, nil, nil, context.Background())
+	wp.Start()
+
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep, Topic: "jobs.tenant-a"}
+	jobs <- models.Job{ID: 2, Type: models.JobTypeSleep, Topic: "jobs.tenant-a"}
+	jobs <- models.Job{ID: 3, Type: models.JobTypeImageResize, Topic: "jobs.tenant-a"}
+	close(jobs)
+	wp.Stop(context.Background())
+	close(results)
+
+	statuses := make(map[int64]models.JobResult)
+	for r := range results {
+		statuses[r.JobID] = r
+	}
+	if statuses[1].Status != models.StatusCompleted || statuses[2].Status != models.StatusCompleted {
+		t.Fatalf("expected allowed jobs to complete, got %+v", statuses)
+	}
+	if r := statuses[3]; r.Status != models.StatusFailed || r.Error != "job type IMAGE_RESIZE is not allowed in topic jobs.tenant-a" {
+		t.Fatalf("expected job type rejected by topic allowlist, got %+v", r)
+	}
+	if maxRunning.Load() != 1 {
+		t.Errorf("expected at most 1 job of the topic at a time, got %d", maxRunning.Load())
+	}
+}
+
+func TestTopicQueueDoesNotOccupyWorkers(t *testing.T) {
+	cfg := &config.Config{
+		Reloadable: config.Reloadable{
+			WorkerPoolSize: 2,
+			MaxJobTimeout:  5 * time.Second,
+		},
+		Topics: map[string]config.TopicConfig{
+			"jobs.tenant-a": {Concurrency: 1},
+		},
+	}
+	jobs := make(chan models.Job, 4)
+	results := make(chan models.JobResult, 4)
+	release := make(chan struct{})
+	executors := map[models.JobType]jobregistry.Executor{
+		models.JobTypeSleep: func(context.Context, string) (any, error) {
+			<-release
+			return nil, nil
+		},
+		models.JobTypeHttpGet: func(context.Context, string) (any, error) {
+			return nil, nil
+		},
+	}
+
+	wp := worker.NewWorkerPool(cfg, jobs, results, nil, executors, nil, nil, nil, context.Background())
+	wp.Start()
+
+	jobs <- models.Job{ID: 1, Type: models.JobTypeSleep, Topic: "jobs.tenant-a"}
+	jobs <- models.Job{ID: 2, Type: models.JobTypeSleep, Topic: "jobs.tenant-a"}
+	jobs <- models.Job{ID: 3, Type: models.JobTypeHttpGet, Topic: "jobs.tenant-b"}
+
+	// Вторая задача топика ждет в очереди топика, свободный воркер выполняет задачу другого топика
+	select {
+	case r := <-results:
+		if r.JobID != 3 {
+			t.Fatalf("expected job of another topic first, got %d", r.JobID)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("job of another topic is blocked by topic limit")
+	}
+	waitFor(t, func() bool {
+		running, queued := wp.Load()
+		return running == 1 && queued == 1
+	})
+	if wp.Idle() {
+		t.Error("pool with queued topic jobs must not be idle")
+	}
+
+	close(release)
+	close(jobs)
+	wp.Stop(context.Background())
+	close(results)
+
+	var done []int64
+	for r := range results {
+		done = append(done, r.JobID)
+	}
+	slices.Sort(done)
+	if !slices.Equal(done, []int64{1, 2}) {
+		t.Errorf("expected both topic jobs completed, got %v", done)
+	}
+	if !wp.Idle() {
+		t.Error("expected pool idle after stop")
+	}
+}
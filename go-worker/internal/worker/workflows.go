@@ -0,0 +1,14 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// Workflows продолжает workflow по результату задачи WORKFLOW или ее шага:
+// публикует следующие шаги и возвращает итог задачи WORKFLOW, когда он готов.
+// Второе значение false означает, что отправлять пока нечего.
+type Workflows interface {
+	Continue(ctx context.Context, job models.Job, result models.JobResult) (models.JobResult, bool)
+}
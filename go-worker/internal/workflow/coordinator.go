@@ -0,0 +1,209 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/joblog"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// continueTimeout ограничивает обновление состояния и публикацию шагов.
+// Они выполняются и после отмены контекста задачи при остановке, иначе
+// результат прерванного шага потерялся бы вместе с workflow.
+const continueTimeout = 30 * time.Second
+
+// Coordinator продолжает workflow по результатам задачи WORKFLOW и ее шагов
+// (см. worker.Workflows).
+type Coordinator struct {
+	store      Store
+	dispatcher Dispatcher
+}
+
+func NewCoordinator(store Store, dispatcher Dispatcher) *Coordinator {
+	return &Coordinator{store: store, dispatcher: dispatcher}
+}
+
+// Continue обрабатывает результат задачи WORKFLOW или шага. Возвращает итог
+// задачи WORKFLOW, когда workflow завершен; до этого второе значение false.
+func (c *Coordinator) Continue(ctx context.Context, job models.Job, result models.JobResult) (models.JobResult, bool) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), continueTimeout)
+	defer cancel()
+
+	if job.Workflow == nil {
+		return c.start(ctx, job, result)
+	}
+	return c.stepDone(ctx, job, result)
+}
+
+// start создает состояние по проверенному определению из результата задачи
+// WORKFLOW и публикует шаги без зависимостей.
+func (c *Coordinator) start(ctx context.Context, job models.Job, result models.JobResult) (models.JobResult, bool) {
+	if result.Status != models.StatusCompleted {
+		// Определение не прошло проверку
+		return result, true
+	}
+	logger := joblog.FromContext(ctx)
+
+	var def models.PayloadWorkflow
+	if err := json.Unmarshal(result.Result, &def); err != nil {
+		result.Status = models.StatusFailed
+		result.Result = nil
+		result.Error = fmt.Sprintf("invalid workflow definition: %v", err)
+		return result, true
+	}
+
+	var (
+		tasks     []*pb.JobTask
+		duplicate bool
+		final     *models.JobResult
+	)
+	err := c.store.Update(ctx, job.ID, func(st *State) (*State, error) {
+		tasks, duplicate, final = nil, false, nil
+		if st != nil && st.Run >= job.Attempt {
+			// Повторная доставка задачи WORKFLOW: workflow уже запущен или завершен
+			duplicate, final = true, st.Final
+			return st, nil
+		}
+		// Новая попытка задачи WORKFLOW начинает workflow заново;
+		// результаты шагов прошлой попытки игнорируются
+		st = newState(job, def)
+		ready, _ := st.advance()
+		var err error
+		tasks, err = st.tasks(ready)
+		return st, err
+	})
+	if err != nil {
+		logger.Error("Failed to save workflow state", slog.String("error", err.Error()))
+		return failure(job, fmt.Errorf("failed to save workflow state: %w", err)), true
+	}
+	if duplicate && final != nil {
+		logger.Info("Duplicate workflow job, re-sending result of finished workflow")
+		res := *final
+		res.TraceHeaders = job.TraceHeaders
+		return res, true
+	}
+	if duplicate {
+		logger.Info("Duplicate workflow job, workflow already started")
+		return models.JobResult{}, false
+	}
+
+	if err := c.dispatch(ctx, job, tasks); err != nil {
+		return failure(job, err), true
+	}
+	logger.Info("Workflow started", slog.Int("steps", len(def.Steps)), slog.Int("dispatched", len(tasks)))
+	return models.JobResult{}, false
+}
+
+// stepDone учитывает результат шага и публикует шаги, которые стали готовы.
+func (c *Coordinator) stepDone(ctx context.Context, job models.Job, result models.JobResult) (models.JobResult, bool) {
+	logger := joblog.FromContext(ctx)
+	ref := job.Workflow
+
+	var (
+		tasks []*pb.JobTask
+		final *models.JobResult
+		stale bool
+	)
+	err := c.store.Update(ctx, job.ID, func(st *State) (*State, error) {
+		tasks, final, stale = nil, nil, false
+		if st == nil || st.Run != ref.Run || st.Final != nil {
+			stale = true
+			return st, nil
+		}
+		retry, ok := st.record(ref.Step, job.Attempt, result)
+		if !ok {
+			stale = true
+			return st, nil
+		}
+
+		var names []string
+		if retry {
+			names = append(names, ref.Step)
+		}
+		ready, done := st.advance()
+		if done {
+			res := st.result()
+			st.Final, final = &res, &res
+			return st, nil
+		}
+		var err error
+		tasks, err = st.tasks(append(names, ready...))
+		return st, err
+	})
+
+	switch {
+	case err != nil:
+		logger.Error("Failed to update workflow state", slog.String("error", err.Error()))
+		return failure(job, fmt.Errorf("failed to update workflow state: %w", err)), true
+	case stale:
+		// Повторная доставка шага или шаг прошлой попытки workflow
+		logger.Info("Ignoring stale workflow step result")
+		return models.JobResult{}, false
+	case final != nil:
+		workflowsFinished.WithLabelValues(string(final.Status)).Inc()
+		logger.Info("Workflow finished",
+			slog.String("status", string(final.Status)),
+			slog.Duration("duration", final.Duration),
+		)
+		return *final, true
+	}
+
+	if result.Status == models.StatusFailed {
+		logger.Warn("Workflow step failed", slog.String("error", result.Error), slog.Bool("retryable", result.Retryable))
+	}
+	if err := c.dispatch(ctx, job, tasks); err != nil {
+		return failure(job, err), true
+	}
+	return models.JobResult{}, false
+}
+
+// dispatch публикует шаги. Если это не удалось, состояние удаляется: без
+// опубликованных шагов workflow не продолжится, а задача WORKFLOW завершится
+// временной ошибкой и будет повторена управляющим сервисом.
+func (c *Coordinator) dispatch(ctx context.Context, job models.Job, tasks []*pb.JobTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	logger := joblog.FromContext(ctx)
+	if err := c.dispatcher.Dispatch(ctx, job.Topic, tasks); err != nil {
+		logger.Error("Failed to dispatch workflow steps", slog.String("error", err.Error()))
+		if err := c.store.Update(ctx, job.ID, func(*State) (*State, error) { return nil, nil }); err != nil {
+			logger.Error("Failed to delete workflow state", slog.String("error", err.Error()))
+		}
+		return err
+	}
+
+	stepsDispatched.Add(float64(len(tasks)))
+	for _, t := range tasks {
+		logger.Debug("Workflow step dispatched",
+			slog.String("step", t.GetWorkflowStep().GetStep()),
+			slog.Int("step_attempt", int(t.GetAttempt())),
+		)
+	}
+	return nil
+}
+
+// failure — итог задачи WORKFLOW при ошибке хранилища или публикации.
+func failure(job models.Job, err error) models.JobResult {
+	attempt := job.Attempt
+	if job.Workflow != nil {
+		attempt = job.Workflow.Run
+	}
+	workflowsFinished.WithLabelValues(string(models.StatusFailed)).Inc()
+
+	return models.JobResult{
+		JobID:        job.ID,
+		Type:         models.JobTypeWorkflow,
+		Status:       models.StatusFailed,
+		Error:        err.Error(),
+		Retryable:    true,
+		Attempt:      attempt,
+		TraceHeaders: job.TraceHeaders,
+	}
+}
@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/kafkaconn"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/tracing"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	kafkaBatchTimeout = 10 * time.Millisecond // Шаги публикуются синхронно, ждать заполнения батча не нужно
+	kafkaMaxAttempts  = 3
+)
+
+// Dispatcher публикует шаги workflow как задачи в топик topic;
+// пустой topic — топик по умолчанию.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, topic string, tasks []*pb.JobTask) error
+}
+
+// KafkaDispatcher публикует шаги с ключом "job_id/шаг" в топик, из которого
+// получен предыдущий шаг (или KAFKA_TOPIC), откуда их забирает любой воркер.
+// Так шаги workflow остаются в топике своего tenant'а или приоритета.
+type KafkaDispatcher struct {
+	writer       *kafka.Writer
+	defaultTopic string
+}
+
+func NewKafkaDispatcher(cfg *config.Config) (*KafkaDispatcher, error) {
+	transport, err := kafkaconn.Transport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KafkaDispatcher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.KafkaBrokersList...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			MaxAttempts:  kafkaMaxAttempts,
+			BatchTimeout: kafkaBatchTimeout,
+			WriteTimeout: cfg.KafkaWriteTimeout,
+			Transport:    transport,
+		},
+		defaultTopic: cfg.KafkaTopic,
+	}, nil
+}
+
+// Dispatch публикует шаги и ждет подтверждения от всех реплик.
+func (d *KafkaDispatcher) Dispatch(ctx context.Context, topic string, tasks []*pb.JobTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	if topic == "" {
+		topic = d.defaultTopic
+	}
+
+	msgs := make([]kafka.Message, 0, len(tasks))
+	for _, t := range tasks {
+		value, err := proto.Marshal(t)
+		if err != nil {
+			return fmt.Errorf("failed to encode workflow step: %w", err)
+		}
+		msg := kafka.Message{
+			Topic: topic,
+			Key:   fmt.Appendf(nil, "%d/%s", t.GetJobId(), t.GetWorkflowStep().GetStep()),
+			Value: value,
+		}
+		// Шаги продолжают трассу задачи WORKFLOW
+		otel.GetTextMapPropagator().Inject(ctx, (*tracing.KafkaHeaderCarrier)(&msg.Headers))
+		msgs = append(msgs, msg)
+	}
+
+	if err := d.writer.WriteMessages(ctx, msgs...); err != nil {
+		return fmt.Errorf("failed to publish workflow steps: %w", err)
+	}
+	return nil
+}
+
+func (d *KafkaDispatcher) Close() error {
+	return d.writer.Close()
+}
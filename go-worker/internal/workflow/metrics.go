@@ -0,0 +1,23 @@
+package workflow
+
+import (
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	stepsDispatched = promauto.With(metrics.Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "workflow",
+		Name:      "steps_dispatched_total",
+		Help:      "Workflow steps and compensations published to the jobs topic, including retries.",
+	})
+
+	workflowsFinished = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "workflow",
+		Name:      "finished_total",
+		Help:      "Finished workflows by final status.",
+	}, []string{"status"})
+)
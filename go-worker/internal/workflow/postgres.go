@@ -0,0 +1,120 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // драйвер "pgx" для database/sql
+)
+
+const (
+	createTableQuery = `
+CREATE TABLE IF NOT EXISTS workflow_state (
+    job_id     BIGINT PRIMARY KEY,
+    state      JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+)`
+
+	createIndexQuery = `CREATE INDEX IF NOT EXISTS workflow_state_updated_at ON workflow_state (updated_at)`
+
+	// Блокировка по job_id сериализует и создание состояния, когда строки еще нет
+	lockQuery = `SELECT pg_advisory_xact_lock($1)`
+
+	selectQuery = `
+SELECT state FROM workflow_state
+WHERE job_id = $1 AND updated_at > now() - $2 * interval '1 millisecond'`
+
+	upsertQuery = `
+INSERT INTO workflow_state (job_id, state, updated_at)
+VALUES ($1, $2, now())
+ON CONFLICT (job_id) DO UPDATE SET state = EXCLUDED.state, updated_at = EXCLUDED.updated_at`
+
+	deleteQuery = `DELETE FROM workflow_state WHERE job_id = $1`
+
+	deleteExpiredQuery = `DELETE FROM workflow_state WHERE updated_at < now() - $1 * interval '1 millisecond'`
+)
+
+// PostgresStore — общее для всех воркеров хранилище в PostgreSQL.
+type PostgresStore struct {
+	db  *sql.DB
+	ttl time.Duration
+}
+
+func NewPostgresStore(ctx context.Context, dsn string, ttl time.Duration) (*PostgresStore, error) {
+	if dsn == "" {
+		return nil, errors.New("WORKFLOW_POSTGRES_DSN is required for postgres workflow store")
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres: %w", err)
+	}
+	for _, q := range []string{createTableQuery, createIndexQuery} {
+		if _, err := db.ExecContext(ctx, q); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to create workflow_state table: %w", err)
+		}
+	}
+
+	return &PostgresStore{db: db, ttl: ttl}, nil
+}
+
+func (s *PostgresStore) Update(ctx context.Context, jobID int64, fn func(st *State) (*State, error)) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, lockQuery, jobID); err != nil {
+		return fmt.Errorf("failed to lock workflow %d: %w", jobID, err)
+	}
+
+	var current *State
+	var data []byte
+	err = tx.QueryRowContext(ctx, selectQuery, jobID, s.ttl.Milliseconds()).Scan(&data)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to load workflow %d: %w", jobID, err)
+	default:
+		current = &State{}
+		if err := json.Unmarshal(data, current); err != nil {
+			return fmt.Errorf("failed to decode workflow state: %w", err)
+		}
+	}
+
+	next, err := fn(current)
+	if err != nil {
+		return err
+	}
+
+	if next == nil {
+		if _, err := tx.ExecContext(ctx, deleteQuery, jobID); err != nil {
+			return fmt.Errorf("failed to delete workflow %d: %w", jobID, err)
+		}
+		return tx.Commit()
+	}
+
+	if current == nil {
+		// Новый workflow: заодно удаляем состояния зависших
+		if _, err := tx.ExecContext(ctx, deleteExpiredQuery, s.ttl.Milliseconds()); err != nil {
+			return fmt.Errorf("failed to delete expired workflows: %w", err)
+		}
+	}
+	if data, err = json.Marshal(next); err != nil {
+		return fmt.Errorf("failed to encode workflow state: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, upsertQuery, jobID, data); err != nil {
+		return fmt.Errorf("failed to save workflow %d: %w", jobID, err)
+	}
+	return tx.Commit()
+}
+
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,291 @@
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+// compensationPrefix — префикс имени компенсирующего действия шага.
+const compensationPrefix = "compensate:"
+
+// StepStatus — состояние шага workflow.
+type StepStatus string
+
+const (
+	StepPending   StepStatus = "pending"
+	StepRunning   StepStatus = "running"
+	StepCompleted StepStatus = "completed"
+	StepFailed    StepStatus = "failed"
+	StepSkipped   StepStatus = "skipped" // Не запускался: workflow остановлен ошибкой другого шага
+)
+
+// StepState — состояние шага или компенсирующего действия.
+type StepState struct {
+	Status  StepStatus      `json:"status"`
+	Attempt int             `json:"attempt,omitempty"`
+	Output  json.RawMessage `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// State — состояние workflow, общее для воркеров, выполняющих его шаги.
+type State struct {
+	JobID      int64                  `json:"job_id"`
+	Run        int                    `json:"run"` // Попытка задачи WORKFLOW
+	Definition models.PayloadWorkflow `json:"definition"`
+	Steps      map[string]*StepState  `json:"steps"` // Шаги и компенсирующие действия
+
+	Completed    []string `json:"completed,omitempty"`   // Порядок завершения шагов, для компенсации
+	FailedStep   string   `json:"failed_step,omitempty"` // Шаг, остановивший workflow
+	Compensating bool     `json:"compensating,omitempty"`
+
+	TraceHeaders map[string]string `json:"trace_headers,omitempty"`
+	StartedAt    time.Time         `json:"started_at"`
+
+	// Итог завершенного workflow. Состояние хранится до WORKFLOW_TTL, чтобы
+	// повторная доставка задачи WORKFLOW получила итог, а не запустила workflow заново
+	Final *models.JobResult `json:"final,omitempty"`
+}
+
+func newState(job models.Job, def models.PayloadWorkflow) *State {
+	st := &State{
+		JobID:        job.ID,
+		Run:          job.Attempt,
+		Definition:   def,
+		Steps:        make(map[string]*StepState, len(def.Steps)),
+		TraceHeaders: job.TraceHeaders,
+		StartedAt:    time.Now(),
+	}
+	for _, step := range def.Steps {
+		st.Steps[step.Name] = &StepState{Status: StepPending}
+	}
+	return st
+}
+
+// step возвращает определение шага по имени шага или его компенсирующего действия.
+func (s *State) step(name string) (*models.WorkflowStep, bool) {
+	stepName, compensation := strings.CutPrefix(name, compensationPrefix)
+	for i := range s.Definition.Steps {
+		if s.Definition.Steps[i].Name == stepName {
+			return &s.Definition.Steps[i], compensation
+		}
+	}
+	return nil, compensation
+}
+
+// record учитывает результат попытки шага или компенсирующего действия.
+// ok == false — результат не относится к текущей попытке (повторная доставка).
+// retry — временная ошибка, шаг нужно опубликовать снова со следующей попыткой.
+func (s *State) record(name string, attempt int, result models.JobResult) (retry, ok bool) {
+	st := s.Steps[name]
+	if st == nil || st.Status != StepRunning || st.Attempt != attempt {
+		return false, false
+	}
+	step, compensation := s.step(name)
+
+	if result.Status == models.StatusCompleted {
+		st.Status, st.Output = StepCompleted, result.Result
+		if !compensation {
+			s.Completed = append(s.Completed, name)
+		}
+		return false, true
+	}
+
+	if result.Retryable && attempt < step.MaxAttempts {
+		st.Attempt++
+		return true, true
+	}
+
+	st.Status, st.Error = StepFailed, result.Error
+	if !compensation && step.OnFailure != models.WorkflowContinue && s.FailedStep == "" {
+		s.FailedStep = name
+	}
+	return false, true
+}
+
+// advance запускает шаги, все зависимости которых выполнены, а после ошибки
+// с политикой compensate — по одному компенсирующие действия завершенных
+// шагов в обратном порядке. Возвращает запущенные шаги и true, если workflow завершен.
+func (s *State) advance() ([]string, bool) {
+	var ready []string
+	if s.FailedStep == "" {
+		for _, step := range s.Definition.Steps {
+			st := s.Steps[step.Name]
+			if st.Status == StepPending && s.dependenciesDone(step) {
+				st.Status, st.Attempt = StepRunning, 1
+				ready = append(ready, step.Name)
+			}
+		}
+	}
+	if len(ready) > 0 || s.running() {
+		return ready, false
+	}
+
+	if failed, _ := s.step(s.FailedStep); failed != nil && failed.OnFailure == models.WorkflowCompensate {
+		if !s.Compensating {
+			s.Compensating = true
+			for _, name := range s.Completed {
+				if step, _ := s.step(name); step.Compensate != nil {
+					s.Steps[compensationPrefix+name] = &StepState{Status: StepPending}
+				}
+			}
+		}
+		for _, name := range slices.Backward(s.Completed) {
+			if st := s.Steps[compensationPrefix+name]; st != nil && st.Status == StepPending {
+				st.Status, st.Attempt = StepRunning, 1
+				return []string{compensationPrefix + name}, false
+			}
+		}
+	}
+
+	for _, st := range s.Steps {
+		if st.Status == StepPending {
+			st.Status = StepSkipped
+		}
+	}
+	return nil, true
+}
+
+// dependenciesDone сообщает, что все зависимости шага завершены успешно
+// или с ошибкой при политике continue.
+func (s *State) dependenciesDone(step models.WorkflowStep) bool {
+	for _, dep := range step.DependsOn {
+		switch s.Steps[dep].Status { //nolint:exhaustive // остальные состояния — зависимость не завершена
+		case StepCompleted, StepFailed:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (s *State) running() bool {
+	for _, st := range s.Steps {
+		if st.Status == StepRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// task собирает JobTask текущей попытки шага: payload из определения
+// и поля inputs из результатов предыдущих шагов.
+func (s *State) task(name string) (*pb.JobTask, error) {
+	step, compensation := s.step(name)
+	jobType, payload, inputs := step.Type, step.Payload, step.Inputs
+	if compensation {
+		jobType, payload, inputs = step.Compensate.Type, step.Compensate.Payload, step.Compensate.Inputs
+	}
+
+	fields := maps.Clone(payload)
+	if fields == nil {
+		fields = make(map[string]any, len(inputs))
+	}
+	for field, ref := range inputs {
+		// Результата нет, если шаг завершился ошибкой с политикой continue
+		if v, ok := s.output(ref); ok {
+			fields[field] = v
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload of step %q: %w", name, err)
+	}
+
+	return &pb.JobTask{
+		JobId:     s.JobID,
+		TypeName:  string(jobType),
+		Payload:   string(data),
+		CreatedAt: time.Now().Unix(),
+		Attempt:   int32(s.Steps[name].Attempt),
+		WorkflowStep: &pb.WorkflowStep{
+			Step: name,
+			Run:  int32(s.Run),
+		},
+	}, nil
+}
+
+func (s *State) tasks(names []string) ([]*pb.JobTask, error) {
+	tasks := make([]*pb.JobTask, 0, len(names))
+	for _, name := range names {
+		t, err := s.task(name)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+// output возвращает значение из результата шага по ссылке "шаг.путь.в.результате".
+// Ссылка без пути — весь результат.
+func (s *State) output(ref string) (any, bool) {
+	name, path, _ := strings.Cut(ref, ".")
+	st := s.Steps[name]
+	if st == nil || st.Status != StepCompleted || len(st.Output) == 0 {
+		return nil, false
+	}
+
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(st.Output))
+	dec.UseNumber() // Целые числа передаются без потери точности
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	if path == "" {
+		return v, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// stepReport — итог шага в результате задачи WORKFLOW.
+type stepReport struct {
+	Status   StepStatus      `json:"status"`
+	Attempts int             `json:"attempts,omitempty"`
+	Output   json.RawMessage `json:"output,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// result — итог задачи WORKFLOW: неуспешен, если шаг с политикой abort
+// или compensate завершился ошибкой.
+func (s *State) result() models.JobResult {
+	report := struct {
+		Steps map[string]stepReport `json:"steps"`
+	}{Steps: make(map[string]stepReport, len(s.Steps))}
+	for name, st := range s.Steps {
+		report.Steps[name] = stepReport{Status: st.Status, Attempts: st.Attempt, Output: st.Output, Error: st.Error}
+	}
+
+	res := models.JobResult{
+		JobID:        s.JobID,
+		Type:         models.JobTypeWorkflow,
+		Status:       models.StatusCompleted,
+		Duration:     time.Since(s.StartedAt),
+		Attempt:      s.Run,
+		TraceHeaders: s.TraceHeaders,
+	}
+	if data, err := json.Marshal(report); err == nil {
+		res.Result = data
+	}
+	if s.FailedStep != "" {
+		res.Status = models.StatusFailed
+		res.Error = fmt.Sprintf("step %q failed: %s", s.FailedStep, s.Steps[s.FailedStep].Error)
+	}
+	return res
+}
@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+)
+
+// Store — хранилище состояний workflow. Результаты шагов одного workflow
+// обрабатывают разные воркеры, поэтому изменения сериализуются в Update.
+type Store interface {
+	// Update атомарно читает состояние workflow jobID, вызывает fn и сохраняет
+	// возвращенное состояние; nil удаляет его. fn получает nil, если состояния
+	// нет или оно не менялось дольше TTL. Ошибка fn отменяет изменение.
+	Update(ctx context.Context, jobID int64, fn func(st *State) (*State, error)) error
+	Close() error
+}
+
+// NewStore создает хранилище по WORKFLOW_STORE.
+func NewStore(ctx context.Context, cfg *config.Config) (Store, error) {
+	switch cfg.WorkflowStore {
+	case "memory", "":
+		return NewMemoryStore(cfg.WorkflowTTL), nil
+	case "postgres":
+		return NewPostgresStore(ctx, cfg.WorkflowPostgresDSN, cfg.WorkflowTTL)
+	default:
+		return nil, fmt.Errorf("unknown WORKFLOW_STORE %q", cfg.WorkflowStore)
+	}
+}
+
+// memorySweepInterval — как часто MemoryStore удаляет устаревшие состояния.
+const memorySweepInterval = time.Minute
+
+type memoryEntry struct {
+	data      []byte
+	updatedAt time.Time
+}
+
+// MemoryStore хранит состояния в памяти процесса. Подходит только для одного
+// воркера и теряет выполняемые workflow при перезапуске.
+type MemoryStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	states    map[int64]memoryEntry
+	lastSweep time.Time
+}
+
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		ttl:       ttl,
+		states:    make(map[int64]memoryEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *MemoryStore) Update(_ context.Context, jobID int64, fn func(st *State) (*State, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= memorySweepInterval {
+		for id, e := range s.states {
+			if now.Sub(e.updatedAt) >= s.ttl {
+				delete(s.states, id)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	// Состояние хранится в JSON, как в postgres: fn не может изменить его в обход Update
+	var current *State
+	if e, ok := s.states[jobID]; ok && now.Sub(e.updatedAt) < s.ttl {
+		current = &State{}
+		if err := json.Unmarshal(e.data, current); err != nil {
+			return fmt.Errorf("failed to decode workflow state: %w", err)
+		}
+	}
+
+	next, err := fn(current)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		delete(s.states, jobID)
+		return nil
+	}
+
+	data, err := json.Marshal(next)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow state: %w", err)
+	}
+	s.states[jobID] = memoryEntry{data: data, updatedAt: now}
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,186 @@
+// Package workflow выполняет задачи WORKFLOW: шаги с зависимостями (DAG),
+// которые воркер публикует в топик задач как отдельные JobTask, передавая
+// в payload шага результаты предыдущих шагов.
+//
+// Задача WORKFLOW только проверяет определение. Следующие шаги публикует
+// Coordinator по результату каждого шага; итог задачи WORKFLOW отправляется
+// управляющему сервису, когда все шаги завершены.
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+)
+
+func init() {
+	jobregistry.RegisterTyped(
+		models.JobTypeWorkflow,
+		pb.JobTask_UNKNOWN_TYPE,
+		func(cfg *config.Config) jobregistry.TypedExecutor[models.PayloadWorkflow] {
+			return execute
+		},
+	)
+}
+
+const defaultMaxAttempts = 3
+
+var failurePolicies = []models.WorkflowFailurePolicy{
+	models.WorkflowAbort,
+	models.WorkflowContinue,
+	models.WorkflowCompensate,
+}
+
+// execute проверяет определение workflow и возвращает его с заполненными
+// значениями по умолчанию. Шаги запускает Coordinator по этому результату.
+func execute(_ context.Context, def *models.PayloadWorkflow) (any, error) {
+	normalize(def)
+	if errs := validateDefinition(def); len(errs) > 0 {
+		return nil, &jobregistry.ValidationError{Fields: errs}
+	}
+	return def, nil
+}
+
+func normalize(def *models.PayloadWorkflow) {
+	if def.OnFailure == "" {
+		def.OnFailure = models.WorkflowAbort
+	}
+	for i := range def.Steps {
+		step := &def.Steps[i]
+		if step.OnFailure == "" {
+			step.OnFailure = def.OnFailure
+		}
+		if step.MaxAttempts == 0 {
+			step.MaxAttempts = defaultMaxAttempts
+		}
+	}
+}
+
+// validateDefinition проверяет шаги: уникальные имена, известные типы задач,
+// зависимости без циклов и inputs, ссылающиеся только на предшествующие шаги.
+func validateDefinition(def *models.PayloadWorkflow) []jobregistry.FieldError {
+	var errs []jobregistry.FieldError
+	fail := func(field, format string, args ...any) {
+		errs = append(errs, jobregistry.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	known := jobregistry.Names()
+	checkType := func(field string, jobType models.JobType) {
+		switch {
+		case jobType == "":
+			fail(field, "is required")
+		case jobType == models.JobTypeWorkflow:
+			fail(field, "nested workflows are not supported")
+		case !slices.Contains(known, jobType):
+			fail(field, "unknown job type %q", jobType)
+		}
+	}
+
+	index := make(map[string]int, len(def.Steps))
+	for i, step := range def.Steps {
+		field := fmt.Sprintf("steps[%d]", i)
+		switch _, dup := index[step.Name]; {
+		case step.Name == "":
+			fail(field+".name", "is required")
+		case strings.ContainsAny(step.Name, "./:"):
+			fail(field+".name", "must not contain '.', '/' or ':'")
+		case dup:
+			fail(field+".name", "duplicate step %q", step.Name)
+		}
+		index[step.Name] = i
+
+		checkType(field+".type", step.Type)
+		if !slices.Contains(failurePolicies, step.OnFailure) {
+			fail(field+".on_failure", "must be one of: abort, continue, compensate")
+		}
+		if step.MaxAttempts < 1 || step.MaxAttempts > 10 {
+			fail(field+".max_attempts", "value must be in [1, 10]")
+		}
+		if step.Compensate != nil {
+			checkType(field+".compensate.type", step.Compensate.Type)
+		}
+	}
+	for i, step := range def.Steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := index[dep]; !ok {
+				fail(fmt.Sprintf("steps[%d].depends_on", i), "unknown step %q", dep)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	ancestors, ok := resolveAncestors(def)
+	if !ok {
+		fail("steps", "dependencies contain a cycle")
+		return errs
+	}
+
+	for i, step := range def.Steps {
+		field := fmt.Sprintf("steps[%d]", i)
+		checkInputs(field+".inputs", step.Inputs, ancestors[step.Name], fail)
+		if step.Compensate != nil {
+			// Компенсация может использовать и результат самого шага
+			allowed := map[string]bool{step.Name: true}
+			for name := range ancestors[step.Name] {
+				allowed[name] = true
+			}
+			checkInputs(field+".compensate.inputs", step.Compensate.Inputs, allowed, fail)
+		}
+	}
+	return errs
+}
+
+func checkInputs(field string, inputs map[string]string, allowed map[string]bool, fail func(field, format string, args ...any)) {
+	for name, ref := range inputs {
+		step, _, _ := strings.Cut(ref, ".")
+		switch {
+		case name == "":
+			fail(field, "input field name is required")
+		case !allowed[step]:
+			fail(field+"."+name, "step %q is not a dependency", step)
+		}
+	}
+}
+
+// resolveAncestors возвращает для каждого шага все шаги, от которых он
+// зависит прямо или транзитивно. false — в зависимостях есть цикл.
+func resolveAncestors(def *models.PayloadWorkflow) (map[string]map[string]bool, bool) {
+	ancestors := make(map[string]map[string]bool, len(def.Steps))
+	for len(ancestors) < len(def.Steps) {
+		progressed := false
+		for _, step := range def.Steps {
+			if ancestors[step.Name] != nil {
+				continue
+			}
+			set := make(map[string]bool)
+			resolved := true
+			for _, dep := range step.DependsOn {
+				depSet, ok := ancestors[dep]
+				if !ok {
+					resolved = false
+					break
+				}
+				set[dep] = true
+				for name := range depSet {
+					set[name] = true
+				}
+			}
+			if resolved {
+				ancestors[step.Name] = set
+				progressed = true
+			}
+		}
+		if !progressed {
+			return nil, false
+		}
+	}
+	return ancestors, true
+}
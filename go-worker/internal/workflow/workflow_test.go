@@ -0,0 +1,274 @@
+package workflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/config"
+	_ "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/executor"
+	pb "github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/gen"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/jobregistry"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/models"
+	"github.com/Roman-Samoilenko/distributed-job-processing-platform/go-worker/internal/workflow"
+)
+
+type fakeDispatcher struct {
+	mu    sync.Mutex
+	tasks []*pb.JobTask
+}
+
+func (d *fakeDispatcher) Dispatch(_ context.Context, _ string, tasks []*pb.JobTask) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tasks = append(d.tasks, tasks...)
+	return nil
+}
+
+// take возвращает опубликованные шаги по имени и очищает список.
+func (d *fakeDispatcher) take() map[string]*pb.JobTask {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	res := make(map[string]*pb.JobTask, len(d.tasks))
+	for _, t := range d.tasks {
+		res[t.GetWorkflowStep().GetStep()] = t
+	}
+	d.tasks = nil
+	return res
+}
+
+const rootID = 42
+
+func startWorkflow(t *testing.T, c *workflow.Coordinator, def models.PayloadWorkflow) {
+	t.Helper()
+
+	data, _ := json.Marshal(def)
+	root := models.Job{ID: rootID, Type: models.JobTypeWorkflow, Attempt: 1}
+	if _, ok := c.Continue(context.Background(), root, models.JobResult{
+		JobID:  rootID,
+		Status: models.StatusCompleted,
+		Result: data,
+	}); ok {
+		t.Fatal("workflow finished before its steps ran")
+	}
+}
+
+// finish передает координатору результат шага, опубликованного как task.
+func finish(c *workflow.Coordinator, task *pb.JobTask, result models.JobResult) (models.JobResult, bool) {
+	job := models.Job{
+		ID:       task.GetJobId(),
+		Type:     models.JobType(task.GetTypeName()),
+		Attempt:  int(task.GetAttempt()),
+		Workflow: &models.WorkflowStepRef{Step: task.GetWorkflowStep().GetStep(), Run: int(task.GetWorkflowStep().GetRun())},
+	}
+	result.JobID = job.ID
+	return c.Continue(context.Background(), job, result)
+}
+
+func completed(output string) models.JobResult {
+	return models.JobResult{Status: models.StatusCompleted, Result: json.RawMessage(output)}
+}
+
+func TestCoordinatorFanOutFanInPassesOutputs(t *testing.T) {
+	d := &fakeDispatcher{}
+	c := workflow.NewCoordinator(workflow.NewMemoryStore(time.Hour), d)
+
+	startWorkflow(t, c, models.PayloadWorkflow{
+		OnFailure: models.WorkflowAbort,
+		Steps: []models.WorkflowStep{
+			{Name: "fetch", Type: models.JobTypeHttpGet, Payload: map[string]any{"url": "https://example.com/a.png"}, MaxAttempts: 3},
+			{
+				Name: "resize", Type: models.JobTypeImageResize, DependsOn: []string{"fetch"}, MaxAttempts: 3,
+				Payload: map[string]any{"image_url": "https://example.com/a.png", "height": 100},
+				Inputs:  map[string]string{"width": "fetch.body_length"},
+			},
+			{Name: "upload", Type: models.JobTypeSleep, DependsOn: []string{"fetch"}, MaxAttempts: 3},
+			{
+				Name: "notify", Type: models.JobTypeHttpGet, DependsOn: []string{"resize", "upload"}, MaxAttempts: 3,
+				Inputs: map[string]string{"resized": "resize"},
+			},
+		},
+	})
+
+	tasks := d.take()
+	if len(tasks) != 1 || tasks["fetch"] == nil {
+		t.Fatalf("expected only fetch to start, got %v", tasks)
+	}
+	if _, ok := finish(c, tasks["fetch"], completed(`{"status_code":200,"body_length":640}`)); ok {
+		t.Fatal("workflow finished after first step")
+	}
+
+	// Fan-out: оба зависимых шага запускаются, выход fetch подставлен в payload
+	tasks = d.take()
+	if len(tasks) != 2 || tasks["resize"] == nil || tasks["upload"] == nil {
+		t.Fatalf("expected resize and upload to start, got %v", tasks)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(tasks["resize"].GetPayload()), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload["width"] != float64(640) || payload["height"] != float64(100) {
+		t.Fatalf("unexpected resize payload: %v", payload)
+	}
+
+	// Временная ошибка: шаг публикуется снова со следующей попыткой
+	finish(c, tasks["resize"], models.JobResult{Status: models.StatusFailed, Error: "timeout", Retryable: true})
+	retry := d.take()["resize"]
+	if retry == nil || retry.GetAttempt() != 2 {
+		t.Fatalf("expected resize retry with attempt 2, got %v", retry)
+	}
+
+	// Fan-in: notify ждет оба шага; повторная доставка результата игнорируется
+	finish(c, tasks["upload"], completed(`{"slept_ms":1}`))
+	finish(c, tasks["upload"], completed(`{"slept_ms":1}`))
+	if len(d.take()) != 0 {
+		t.Fatal("notify started before resize finished")
+	}
+	finish(c, retry, completed(`{"width":640,"height":100}`))
+	notify := d.take()["notify"]
+	if notify == nil {
+		t.Fatal("notify did not start after resize and upload")
+	}
+
+	final, ok := finish(c, notify, completed(`{"status_code":204}`))
+	if !ok || final.Status != models.StatusCompleted || final.JobID != rootID || final.Type != models.JobTypeWorkflow {
+		t.Fatalf("unexpected final result: ok=%v %+v", ok, final)
+	}
+	var report struct {
+		Steps map[string]struct {
+			Status   string `json:"status"`
+			Attempts int    `json:"attempts"`
+		} `json:"steps"`
+	}
+	if err := json.Unmarshal(final.Result, &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Steps) != 4 || report.Steps["resize"].Status != "completed" || report.Steps["resize"].Attempts != 2 {
+		t.Fatalf("unexpected workflow report: %s", final.Result)
+	}
+}
+
+func TestCoordinatorCompensatesCompletedStepsInReverseOrder(t *testing.T) {
+	d := &fakeDispatcher{}
+	c := workflow.NewCoordinator(workflow.NewMemoryStore(time.Hour), d)
+
+	undo := func(what string) *models.WorkflowAction {
+		return &models.WorkflowAction{Type: models.JobTypeHttpGet, Payload: map[string]any{"undo": what}}
+	}
+	startWorkflow(t, c, models.PayloadWorkflow{
+		OnFailure: models.WorkflowCompensate,
+		Steps: []models.WorkflowStep{
+			{Name: "reserve", Type: models.JobTypeSleep, MaxAttempts: 1, OnFailure: models.WorkflowCompensate, Compensate: undo("reserve")},
+			{Name: "charge", Type: models.JobTypeSleep, DependsOn: []string{"reserve"}, MaxAttempts: 1,
+				OnFailure: models.WorkflowCompensate, Compensate: undo("charge")},
+			{Name: "ship", Type: models.JobTypeSleep, DependsOn: []string{"charge"}, MaxAttempts: 1, OnFailure: models.WorkflowCompensate},
+			{Name: "notify", Type: models.JobTypeSleep, DependsOn: []string{"ship"}, MaxAttempts: 1, OnFailure: models.WorkflowCompensate},
+		},
+	})
+
+	finish(c, d.take()["reserve"], completed(`{}`))
+	finish(c, d.take()["charge"], completed(`{}`))
+	finish(c, d.take()["ship"], models.JobResult{Status: models.StatusFailed, Error: "out of stock"})
+
+	var order []string
+	for {
+		tasks := d.take()
+		if len(tasks) != 1 {
+			t.Fatalf("expected one compensation at a time, got %v", tasks)
+		}
+		for name, task := range tasks {
+			order = append(order, name)
+			if final, ok := finish(c, task, completed(`{}`)); ok {
+				if final.Status != models.StatusFailed || final.Error != `step "ship" failed: out of stock` {
+					t.Fatalf("unexpected final result: %+v", final)
+				}
+				if len(order) != 2 || order[0] != "compensate:charge" || order[1] != "compensate:reserve" {
+					t.Fatalf("unexpected compensation order: %v", order)
+				}
+				return
+			}
+		}
+	}
+}
+
+func TestWorkflowJobRejectsInvalidDefinition(t *testing.T) {
+	exec := jobregistry.CreateExecutors(&config.Config{}, nil)[models.JobTypeWorkflow]
+
+	_, err := exec(context.Background(), `{"steps": [
+		{"name": "a", "type": "SLEEP", "depends_on": ["b"]},
+		{"name": "b", "type": "SLEEP", "depends_on": ["a"]}
+	]}`)
+	var verr *jobregistry.ValidationError
+	if !errors.As(err, &verr) || verr.Fields[0].Message != "dependencies contain a cycle" {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+
+	_, err = exec(context.Background(), `{"steps": [
+		{"name": "a", "type": "SLEEP"},
+		{"name": "b", "type": "SLEEP", "inputs": {"width": "c.width"}},
+		{"name": "c", "type": "WORKFLOW", "depends_on": ["a"]}
+	]}`)
+	if !errors.As(err, &verr) || len(verr.Fields) != 1 || verr.Fields[0].Field != "steps[2].type" {
+		t.Fatalf("expected nested workflow error, got %v", err)
+	}
+
+	out, err := exec(context.Background(), `{"steps": [{"name": "a", "type": "SLEEP", "payload": {"duration_ms": 1}}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := out.(*models.PayloadWorkflow)
+	if def.OnFailure != models.WorkflowAbort || def.Steps[0].MaxAttempts != 3 {
+		t.Fatalf("defaults not applied: %+v", def)
+	}
+}
+
+func TestRedeliveredFinishedWorkflowResendsResult(t *testing.T) {
+	d := &fakeDispatcher{}
+	c := workflow.NewCoordinator(workflow.NewMemoryStore(time.Hour), d)
+	def := models.PayloadWorkflow{
+		OnFailure: models.WorkflowAbort,
+		Steps:     []models.WorkflowStep{{Name: "only", Type: models.JobTypeSleep, MaxAttempts: 1}},
+	}
+	startWorkflow(t, c, def)
+	step := d.take()["only"]
+	final, ok := finish(c, step, completed(`{}`))
+	if !ok || final.Status != models.StatusCompleted {
+		t.Fatalf("expected workflow to finish, got ok=%v %+v", ok, final)
+	}
+
+	// Повторная доставка завершенной задачи WORKFLOW получает итог без нового запуска
+	data, _ := json.Marshal(def)
+	root := models.Job{ID: rootID, Type: models.JobTypeWorkflow, Attempt: 1}
+	again, ok := c.Continue(context.Background(), root, models.JobResult{JobID: rootID, Status: models.StatusCompleted, Result: data})
+	if !ok || again.Status != final.Status || string(again.Result) != string(final.Result) {
+		t.Fatalf("expected cached workflow result, got ok=%v %+v", ok, again)
+	}
+	if tasks := d.take(); len(tasks) != 0 {
+		t.Fatalf("finished workflow must not be started again, dispatched %v", tasks)
+	}
+	if _, ok := finish(c, step, completed(`{}`)); ok {
+		t.Fatal("step result after finish must be ignored")
+	}
+
+	// Новая попытка задачи WORKFLOW запускает workflow заново; шаг нового запуска — другая задача
+	root.Attempt = 2
+	if _, ok := c.Continue(context.Background(), root, models.JobResult{JobID: rootID, Status: models.StatusCompleted, Result: data}); ok {
+		t.Fatal("new workflow run finished before its steps ran")
+	}
+	rerun := d.take()["only"]
+	if rerun == nil || rerun.GetWorkflowStep().GetRun() != 2 {
+		t.Fatalf("expected step of run 2, got %v", rerun)
+	}
+	key := func(task *pb.JobTask) string {
+		return models.Job{ID: task.GetJobId(), Workflow: &models.WorkflowStepRef{
+			Step: task.GetWorkflowStep().GetStep(), Run: int(task.GetWorkflowStep().GetRun()),
+		}}.Key()
+	}
+	if key(step) != "42/1/only" || key(rerun) != "42/2/only" {
+		t.Errorf("unexpected step keys %q and %q", key(step), key(rerun))
+	}
+}
@@ -2,6 +2,7 @@ package com.jobplatform;
 
 import org.springframework.boot.SpringApplication;
 import org.springframework.boot.autoconfigure.SpringBootApplication;
+import org.springframework.scheduling.annotation.EnableScheduling;
 
 /**
  * Точка входа в Spring Boot приложение.
@@ -10,8 +11,11 @@ import org.springframework.boot.autoconfigure.SpringBootApplication;
  * - @Configuration: этот класс может содержать Spring beans
  * - @EnableAutoConfiguration: автоматически настраивает Spring на основе зависимостей
  * - @ComponentScan: ищет компоненты (@Controller, @Service, @Repository) в этом пакете
+ * 
+ * @EnableScheduling включает периодический поиск задач потерянных воркеров.
  */
 @SpringBootApplication
+@EnableScheduling
 public class JobPlatformApplication {
     
     public static void main(String[] args) {
@@ -68,6 +68,38 @@ public class Job {
     @Column(name = "error_message", columnDefinition = "TEXT")
     private String errorMessage;
     
+    /**
+     * Номер текущей попытки выполнения. Увеличивается, когда задача
+     * переназначается после потери воркера.
+     */
+    @Column(nullable = false, columnDefinition = "integer default 1")
+    private Integer attempt = 1;
+    
+    /**
+     * Воркер, который выполняет текущую попытку (из heartbeat).
+     */
+    @Column(name = "worker_id", length = 255)
+    private String workerId;
+    
+    /**
+     * Время последнего heartbeat'а воркера. По нему находятся задачи
+     * потерянных воркеров.
+     */
+    @Column(name = "last_heartbeat_at")
+    private LocalDateTime lastHeartbeatAt;
+    
+    /**
+     * Прогресс выполнения, 0..100 (из ReportJobProgress).
+     */
+    @Column(name = "progress_percent")
+    private Double progressPercent;
+    
+    /**
+     * Текущий этап выполнения, например "download".
+     */
+    @Column(name = "progress_stage", length = 255)
+    private String progressStage;
+    
     /**
      * Время создания записи.
      */
@@ -1,6 +1,7 @@
 package com.jobplatform.grpc;
 
 import com.jobplatform.service.JobService;
+import com.jobplatform.service.WorkerRegistry;
 // ИСПРАВЛЕНИЕ: Импортируем сгенерированные классы напрямую
 import com.jobplatform.grpc.UpdateJobStatusRequest;
 import com.jobplatform.grpc.UpdateJobStatusResponse;
@@ -11,12 +12,17 @@ import lombok.RequiredArgsConstructor;
 import lombok.extern.slf4j.Slf4j;
 import net.devh.boot.grpc.server.service.GrpcService;
 
+import java.time.Instant;
+import java.util.List;
+import java.util.Optional;
+
 @Slf4j
 @GrpcService
 @RequiredArgsConstructor
 public class JobStatusGrpcService extends JobStatusServiceGrpc.JobStatusServiceImplBase {
     
     private final JobService jobService;
+    private final WorkerRegistry workerRegistry;
     
     @Override
     public void updateJobStatus(
@@ -34,7 +40,8 @@ public class JobStatusGrpcService extends JobStatusServiceGrpc.JobStatusServiceI
                 request.getJobId(),
                 status,
                 request.getResult(),
-                request.getErrorMessage()
+                request.getErrorMessage(),
+                request.getAttempt()
             );
             
             UpdateJobStatusResponse response = UpdateJobStatusResponse.newBuilder()
@@ -49,4 +56,83 @@ public class JobStatusGrpcService extends JobStatusServiceGrpc.JobStatusServiceI
             responseObserver.onError(e);
         }
     }
+    
+    /**
+     * Прогресс долгой задачи. Неизвестная задача — success=false, без ошибки RPC:
+     * прогресс best-effort, воркер его не повторяет.
+     */
+    @Override
+    public void reportJobProgress(
+            JobProgressRequest request,
+            StreamObserver<JobProgressResponse> responseObserver) {
+        
+        try {
+            boolean found = jobService.updateJobProgress(
+                request.getJobId(),
+                request.getPercent(),
+                request.getStage()
+            );
+            
+            responseObserver.onNext(JobProgressResponse.newBuilder().setSuccess(found).build());
+            responseObserver.onCompleted();
+            
+        } catch (Exception e) {
+            log.error("Failed to update job progress", e);
+            responseObserver.onError(e);
+        }
+    }
+    
+    /**
+     * Продление аренды задачи. Воркер прерывает задачу, если аренда отозвана.
+     */
+    @Override
+    public void heartbeat(
+            JobHeartbeatRequest request,
+            StreamObserver<JobHeartbeatResponse> responseObserver) {
+        
+        try {
+            Optional<String> revoked = jobService.heartbeat(
+                request.getJobId(),
+                request.getWorkerId(),
+                request.getAttempt()
+            );
+            
+            JobHeartbeatResponse.Builder response = JobHeartbeatResponse.newBuilder();
+            revoked.ifPresent(reason -> {
+                log.warn("Revoking lease of job {} on worker {}: {}",
+                    request.getJobId(), request.getWorkerId(), reason);
+                response.setLeaseRevoked(true).setReason(reason);
+            });
+            
+            responseObserver.onNext(response.build());
+            responseObserver.onCompleted();
+            
+        } catch (Exception e) {
+            log.error("Failed to process heartbeat", e);
+            responseObserver.onError(e);
+        }
+    }
+    
+    /**
+     * Регистрация воркера и отчет о его нагрузке.
+     */
+    @Override
+    public void registerWorker(
+            WorkerRegistrationRequest request,
+            StreamObserver<WorkerRegistrationResponse> responseObserver) {
+        
+        workerRegistry.register(new WorkerRegistry.WorkerInfo(
+            request.getWorkerId(),
+            request.getVersion(),
+            List.copyOf(request.getJobTypesList()),
+            request.getPoolSize(),
+            request.getInFlight(),
+            request.getQueued(),
+            Instant.ofEpochSecond(request.getStartedAt()),
+            Instant.now()
+        ));
+        
+        responseObserver.onNext(WorkerRegistrationResponse.newBuilder().setAccepted(true).build());
+        responseObserver.onCompleted();
+    }
 }
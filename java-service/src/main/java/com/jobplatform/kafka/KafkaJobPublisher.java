@@ -31,6 +31,7 @@ public class KafkaJobPublisher {
                 .setType(taskType)
                 .setPayload(job.getPayload())
                 .setCreatedAt(job.getCreatedAt().toEpochSecond(ZoneOffset.UTC))
+                .setAttempt(job.getAttempt())
                 .build();
             
             kafkaTemplate.send(topic, String.valueOf(job.getId()), message.toByteArray());
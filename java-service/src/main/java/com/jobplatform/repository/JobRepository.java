@@ -4,6 +4,9 @@ import com.jobplatform.entity.Job;
 import org.springframework.data.jpa.repository.JpaRepository;
 import org.springframework.stereotype.Repository;
 
+import java.time.LocalDateTime;
+import java.util.List;
+
 /**
  * Репозиторий для работы с таблицей jobs.
  * 
@@ -19,5 +22,10 @@ import org.springframework.stereotype.Repository;
  */
 @Repository
 public interface JobRepository extends JpaRepository<Job, Long> {
-    // Пока используем только стандартные методы
+    
+    /**
+     * Задачи в статусе status, heartbeat которых не приходил с cutoff:
+     * воркер, выполнявший их, потерян.
+     */
+    List<Job> findByStatusAndLastHeartbeatAtBefore(String status, LocalDateTime cutoff);
 }
@@ -1,7 +1,11 @@
 package com.jobplatform.service;
 
+import java.time.LocalDateTime;
 import java.util.List;
+import java.util.Optional;
 
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.scheduling.annotation.Scheduled;
 import org.springframework.stereotype.Service;
 import org.springframework.transaction.annotation.Transactional; // Обязательно!
 
@@ -24,6 +28,19 @@ public class JobService {
     private final JobRepository jobRepository;
     private final KafkaJobPublisher kafkaPublisher;
     
+    /**
+     * Через сколько без heartbeat'а задача считается потерянной
+     * и публикуется снова со следующей попыткой.
+     */
+    @Value("${jobs.lease-timeout-ms:60000}")
+    private long leaseTimeoutMs;
+    
+    /**
+     * Сколько попыток дается задаче, воркер которой теряется.
+     */
+    @Value("${jobs.max-attempts:3}")
+    private int maxAttempts;
+    
     // Jackson для конвертации payload объекта в JSON строку
     private final ObjectMapper objectMapper = new ObjectMapper();
     
@@ -95,10 +112,17 @@ public class JobService {
      * @param errorMessage Текст ошибки (только для FAILED)
      */
     @Transactional
-    public void updateJobStatus(Long jobId, String status, String result, String errorMessage) {
+    public void updateJobStatus(Long jobId, String status, String result, String errorMessage, int attempt) {
         Job job = jobRepository.findById(jobId)
             .orElseThrow(() -> new RuntimeException("Job not found: " + jobId));
         
+        // Результат попытки, аренду которой отозвали, опоздал: задача уже переназначена
+        if (attempt > 0 && attempt < job.getAttempt()) {
+            log.warn("Ignoring result of stale attempt {} for job {} (current attempt {})",
+                attempt, jobId, job.getAttempt());
+            return;
+        }
+        
         // Обновляем поля
         job.setStatus(status);
         job.setResult(result);
@@ -108,4 +132,91 @@ public class JobService {
         jobRepository.save(job);
         log.info("Updated job {} to status {}", jobId, status);
     }
+    
+    /**
+     * Продление аренды задачи воркером (gRPC Heartbeat).
+     * 
+     * Heartbeat переводит задачу в IN_PROGRESS и запоминает воркер и время.
+     * Аренда отзывается, если задача уже завершена или переназначена (попытка
+     * воркера устарела). Шаги workflow продлевают аренду задачи WORKFLOW
+     * с разных воркеров, поэтому воркер задачи не закрепляется.
+     * 
+     * @return причина отзыва аренды или пустой Optional, если аренда продлена
+     */
+    @Transactional
+    public Optional<String> heartbeat(Long jobId, String workerId, int attempt) {
+        Optional<Job> found = jobRepository.findById(jobId);
+        if (found.isEmpty()) {
+            return Optional.of("job not found");
+        }
+        Job job = found.get();
+        
+        if ("COMPLETED".equals(job.getStatus()) || "FAILED".equals(job.getStatus())) {
+            return Optional.of("job already finished");
+        }
+        if (Math.max(attempt, 1) < job.getAttempt()) {
+            return Optional.of("job reassigned after attempt " + attempt);
+        }
+        job.setStatus("IN_PROGRESS");
+        job.setWorkerId(workerId);
+        job.setLastHeartbeatAt(LocalDateTime.now());
+        jobRepository.save(job);
+        return Optional.empty();
+    }
+    
+    /**
+     * Сохранение прогресса выполнения (gRPC ReportJobProgress).
+     * Прогресс завершенной задачи не меняется.
+     * 
+     * @return false, если задача не найдена
+     */
+    @Transactional
+    public boolean updateJobProgress(Long jobId, double percent, String stage) {
+        Optional<Job> found = jobRepository.findById(jobId);
+        if (found.isEmpty()) {
+            return false;
+        }
+        Job job = found.get();
+        
+        if (!"COMPLETED".equals(job.getStatus()) && !"FAILED".equals(job.getStatus())) {
+            job.setProgressPercent(percent);
+            job.setProgressStage(stage.isEmpty() ? null : stage);
+            jobRepository.save(job);
+        }
+        return true;
+    }
+    
+    /**
+     * Поиск задач потерянных воркеров: IN_PROGRESS без heartbeat'а дольше
+     * jobs.lease-timeout-ms. Такая задача публикуется в Kafka снова со следующей
+     * попыткой; аренда прошлой попытки отзывается при ее следующем heartbeat'е.
+     * После jobs.max-attempts попыток задача завершается ошибкой.
+     */
+    @Scheduled(fixedDelayString = "${jobs.orphan-check-interval-ms:15000}")
+    @Transactional
+    public void reassignOrphanedJobs() {
+        LocalDateTime cutoff = LocalDateTime.now().minusNanos(leaseTimeoutMs * 1_000_000);
+        
+        for (Job job : jobRepository.findByStatusAndLastHeartbeatAtBefore("IN_PROGRESS", cutoff)) {
+            log.warn("Job {} lost its worker {} (last heartbeat at {})",
+                job.getId(), job.getWorkerId(), job.getLastHeartbeatAt());
+            
+            job.setWorkerId(null);
+            job.setLastHeartbeatAt(null);
+            job.setProgressPercent(null);
+            job.setProgressStage(null);
+            
+            if (job.getAttempt() >= maxAttempts) {
+                job.setStatus("FAILED");
+                job.setErrorMessage("worker lost after " + job.getAttempt() + " attempts");
+                jobRepository.save(job);
+                continue;
+            }
+            
+            job.setAttempt(job.getAttempt() + 1);
+            job.setStatus("CREATED");
+            jobRepository.save(job);
+            kafkaPublisher.publishJob(job);
+        }
+    }
 }
@@ -0,0 +1,62 @@
+package com.jobplatform.service;
+
+import java.time.Duration;
+import java.time.Instant;
+import java.util.List;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.stereotype.Component;
+
+import lombok.extern.slf4j.Slf4j;
+
+/**
+ * Реестр воркеров: возможности и нагрузка из gRPC RegisterWorker.
+ * 
+ * Воркер повторяет регистрацию периодически (REGISTRATION_INTERVAL), поэтому
+ * запись, не обновлявшаяся дольше workers.ttl-ms, считается устаревшей.
+ * Хранится в памяти: после перезапуска сервиса воркеры регистрируются снова.
+ */
+@Slf4j
+@Component
+public class WorkerRegistry {
+    
+    /**
+     * Последний отчет воркера.
+     */
+    public record WorkerInfo(
+        String workerId,
+        String version,
+        List<String> jobTypes,
+        int poolSize,
+        int inFlight,
+        int queued,
+        Instant startedAt,
+        Instant reportedAt
+    ) {}
+    
+    private final Map<String, WorkerInfo> workers = new ConcurrentHashMap<>();
+    
+    @Value("${workers.ttl-ms:90000}")
+    private long ttlMs;
+    
+    /**
+     * Сохраняет отчет воркера. Первая регистрация попадает в лог.
+     */
+    public void register(WorkerInfo info) {
+        if (workers.put(info.workerId(), info) == null) {
+            log.info("Worker {} registered: version {}, job types {}, pool size {}",
+                info.workerId(), info.version(), info.jobTypes(), info.poolSize());
+        }
+    }
+    
+    /**
+     * Воркеры, отчитавшиеся не раньше workers.ttl-ms назад. Устаревшие записи удаляются.
+     */
+    public List<WorkerInfo> activeWorkers() {
+        Instant cutoff = Instant.now().minus(Duration.ofMillis(ttlMs));
+        workers.values().removeIf(w -> w.reportedAt().isBefore(cutoff));
+        return List.copyOf(workers.values());
+    }
+}